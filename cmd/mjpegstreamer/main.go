@@ -6,7 +6,7 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"time"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/gorilla/handlers"
@@ -17,36 +17,62 @@ import (
 
 var listenFlag = flag.String("listen", ":8081", "Listen address")
 var rendererFlag = flag.String("renderer", "localhost:50051", "Renderer address")
+var maxFpsFlag = flag.Int("maxfps", 0, "Maximum frames per second to stream, 0 for the renderer fps")
 var canvasParameters *pb.CanvasParametersResponse
+var parametersMut sync.RWMutex
 
-func fetchParameters(client pb.SixelpingRendererClient) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
+func getParameters() *pb.CanvasParametersResponse {
+	parametersMut.RLock()
+	defer parametersMut.RUnlock()
+	return canvasParameters
+}
+
+func setParameters(parameters *pb.CanvasParametersResponse) {
+	parametersMut.Lock()
+	defer parametersMut.Unlock()
+	canvasParameters = parameters
+}
 
-	parameters, err := client.GetCanvasParameters(ctx, &empty.Empty{})
+//Follow the renderer parameters, returns once the current ones are known
+func watchParameters(client pb.SixelpingRendererClient) {
+	stream, err := client.WatchCanvasParameters(context.Background(), &empty.Empty{})
+	if err != nil {
+		log.Fatalf("Failed to watch renderer parameters: %v", err)
+	}
+	parameters, err := stream.Recv()
 	if err != nil {
-		log.Fatalf("Failed to poll renderer parameters: %v", err)
+		log.Fatalf("Failed to watch renderer parameters: %v", err)
 	}
+	setParameters(parameters)
 
-	canvasParameters = parameters
+	go func() {
+		for {
+			parameters, err := stream.Recv()
+			if err != nil {
+				log.Fatalf("Failed to watch renderer parameters: %v", err)
+			}
+			log.Printf("Renderer parameters changed: %v", parameters)
+			setParameters(parameters)
+		}
+	}()
 }
 
 func poller(client pb.SixelpingRendererClient, streamer *mjpeg.Streamer) {
-	psd := time.Second / time.Duration(int64(canvasParameters.GetFps()))
-	nextTime := time.Now()
+	stream, err := client.StreamRenderedImages(context.Background(), &pb.StreamRenderedImagesRequest{
+		Encoding: pb.ImageEncoding_JPEG,
+		MaxFps:   uint32(*maxFpsFlag),
+	})
+	if err != nil {
+		log.Fatalf("Failed to stream from renderer: %v", err)
+	}
+
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		response, err := client.GetRenderedImage(ctx, &empty.Empty{})
-		if err == nil {
-			bts := response.GetImage()
-			streamer.NewFrame(&bts)
-		} else {
-			log.Fatalf("Failed to poll renderer: %v", err)
+		response, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Failed to stream from renderer: %v", err)
 		}
-
-		nextTime = nextTime.Add(psd)
-		time.Sleep(time.Until(nextTime))
+		bts := response.GetImage()
+		streamer.NewFrame(&bts)
 	}
 }
 
@@ -64,7 +90,7 @@ func main() {
 
 	client := pb.NewSixelpingRendererClient(conn)
 
-	fetchParameters(client)
+	watchParameters(client)
 	log.Println("Starting poller...")
 	go poller(client, streamer)
 
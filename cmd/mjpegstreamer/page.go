@@ -36,8 +36,9 @@ img {
 			Width  uint32
 			Height uint32
 		}
-		dets.Width = canvasParameters.GetWidth()
-		dets.Height = canvasParameters.GetHeight()
+		parameters := getParameters()
+		dets.Width = parameters.GetWidth()
+		dets.Height = parameters.GetHeight()
 		pageTemplate.Execute(w, dets)
 	} else {
 		log.Fatalf("Template invalid: %v", err)
@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+//Renderer state that can be changed at runtime, initialized from the flags
+type runtimeSettings struct {
+	fps          int
+	pixelTimeout uint64
+	logo         image.Image
+	paused       bool
+	frozen       bool
+	watchers     []chan *pb.CanvasParametersResponse
+	mut          sync.RWMutex
+}
+
+var settings *runtimeSettings
+
+func setupSettings() {
+	settings = &runtimeSettings{
+		fps:          *fpsFlag,
+		pixelTimeout: uint64((*pixTimeoutFlag) * 1000000000),
+	}
+	if *logoFlag != "" {
+		settings.logo = readLogo()
+	}
+}
+
+func (s *runtimeSettings) Fps() int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.fps
+}
+
+func (s *runtimeSettings) Logo() image.Image {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.logo
+}
+
+func (s *runtimeSettings) Paused() bool {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.paused
+}
+
+func (s *runtimeSettings) parameters() *pb.CanvasParametersResponse {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.parametersLocked()
+}
+
+func (s *runtimeSettings) parametersLocked() *pb.CanvasParametersResponse {
+	width, height := canvas.Size()
+	return &pb.CanvasParametersResponse{
+		Width:           uint32(width),
+		Height:          uint32(height),
+		Fps:             uint32(s.fps),
+		PixelTimeout:    s.pixelTimeout,
+		IngestionPaused: s.paused,
+		DisplayFrozen:   s.frozen,
+	}
+}
+
+//Watch returns a channel receiving the current parameters and every change.
+//Slow watchers only get the latest parameters.
+func (s *runtimeSettings) Watch() chan *pb.CanvasParametersResponse {
+	c := make(chan *pb.CanvasParametersResponse, 1)
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	c <- s.parametersLocked()
+	s.watchers = append(s.watchers, c)
+	return c
+}
+
+func (s *runtimeSettings) Unwatch(c chan *pb.CanvasParametersResponse) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	newWatchers := make([]chan *pb.CanvasParametersResponse, 0)
+	for _, d := range s.watchers {
+		if d != c {
+			newWatchers = append(newWatchers, d)
+		}
+	}
+	s.watchers = newWatchers
+}
+
+//Send the parameters to all watchers, must be called with the write lock held
+func (s *runtimeSettings) notify() {
+	parameters := s.parametersLocked()
+	for _, c := range s.watchers {
+		// Replace parameters the watcher has not picked up yet
+		select {
+		case <-c:
+		default:
+		}
+		c <- parameters
+	}
+}
+
+//Upper bound for SetFps, rendering is not cheap
+const maxFps = 120
+
+//Upper bound for ResizeCanvas, raw RGBA frames must fit in a gRPC message
+const maxCanvasSize = 4096
+
+type adminServer struct {
+	pb.UnimplementedSixelpingAdminServer
+}
+
+func (s *adminServer) ClearCanvas(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	now := time.Now()
+	source := deltaSource(ctx)
+	clear := func() {
+		publishMut.Lock()
+		defer publishMut.Unlock()
+		if history != nil {
+			history.Clear(canvas, now)
+		} else {
+			canvas.Clear()
+		}
+		if recorder != nil {
+			err := recorder.Append(&pb.DeltaRecord{Timestamp: now.UnixNano(), Source: source, Clear: true})
+			if err != nil {
+				log.Printf("Failed to record clear: %v", err)
+			}
+		}
+	}
+	if delay != nil {
+		delay.clear(clear)
+	} else {
+		clear()
+	}
+	log.Printf("Canvas cleared by %s", source)
+	return &empty.Empty{}, nil
+}
+
+//Fill a region by publishing it as a delta, which bypasses moderation, quotas
+//and the moderation delay but is recorded and kept in the history
+func (s *adminServer) FillRegion(ctx context.Context, req *pb.FillRegionRequest) (*empty.Empty, error) {
+	rect := req.GetRect()
+	if rect == nil {
+		return nil, status.Error(codes.InvalidArgument, "Fill has no rectangle")
+	}
+	width, height := canvas.Size()
+	if uint64(rect.GetX())+uint64(rect.GetWidth()) > uint64(width) || uint64(rect.GetY())+uint64(rect.GetHeight()) > uint64(height) {
+		return nil, status.Errorf(codes.InvalidArgument, "Fill outside of %dx%d canvas", width, height)
+	}
+
+	delta := &pb.NewDeltaImageRequest{Runs: make([]*pb.DeltaRun, rect.GetHeight())}
+	for i := range delta.Runs {
+		delta.Runs[i] = &pb.DeltaRun{X: rect.GetX(), Y: rect.GetY() + uint32(i), Length: rect.GetWidth(), Rgb: req.GetRgb()}
+	}
+	err := publishDelta(time.Now(), deltaSource(ctx), delta)
+	if err != nil {
+		return nil, deltaError(err)
+	}
+	resyncModerator()
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) SetPixelTimeout(ctx context.Context, req *pb.PixelTimeoutRequest) (*empty.Empty, error) {
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	settings.pixelTimeout = req.GetPixelTimeout()
+	canvas.SetPixelTimeout(settings.pixelTimeout)
+	if delay != nil {
+		delay.canvas.SetPixelTimeout(settings.pixelTimeout)
+	}
+	settings.notify()
+	log.Printf("Pixel timeout set to %v", time.Duration(settings.pixelTimeout))
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) SetFps(ctx context.Context, req *pb.FpsRequest) (*empty.Empty, error) {
+	if req.GetFps() < 1 || req.GetFps() > maxFps {
+		return nil, status.Errorf(codes.InvalidArgument, "Fps must be between 1 and %d", maxFps)
+	}
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	settings.fps = int(req.GetFps())
+	producer.SetFps(settings.fps)
+	if delay != nil {
+		delay.producer.SetFps(settings.fps)
+	}
+	settings.notify()
+	log.Printf("Fps set to %d", settings.fps)
+	return &empty.Empty{}, nil
+}
+
+//Unlike the other setters this does not notify watchers, the logo is not part
+//of the canvas parameters and shows up in the rendered frames instead
+func (s *adminServer) SetLogo(ctx context.Context, req *pb.LogoRequest) (*empty.Empty, error) {
+	var logo image.Image
+	if len(req.GetImage()) > 0 {
+		var err error
+		logo, err = png.Decode(bytes.NewReader(req.GetImage()))
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid logo: %v", err)
+		}
+		log.Printf("Loaded a %dx%d logo.", logo.Bounds().Max.X, logo.Bounds().Max.Y)
+	}
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	settings.logo = logo
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) PauseIngestion(ctx context.Context, req *pb.PauseRequest) (*empty.Empty, error) {
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	settings.paused = req.GetPaused()
+	settings.notify()
+	log.Printf("Ingestion paused: %v", settings.paused)
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) FreezeDisplay(ctx context.Context, req *pb.FreezeRequest) (*empty.Empty, error) {
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	settings.frozen = req.GetFrozen()
+	producer.SetFrozen(settings.frozen)
+	if delay != nil {
+		delay.producer.SetFrozen(settings.frozen)
+	}
+	settings.notify()
+	log.Printf("Display frozen: %v", settings.frozen)
+	return &empty.Empty{}, nil
+}
+
+//Serve the admin service on its own listener, so it can be firewalled
+//separately
+func serveAdmin() {
+	lis, err := net.Listen("tcp", *adminListenFlag)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer(
+		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		grpc.MaxRecvMsgSize(64000000),
+	)
+	pb.RegisterSixelpingAdminServer(s, &adminServer{})
+	reflection.Register(s)
+	grpc_prometheus.Register(s)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("failed to serve admin: %v", err)
+	}
+}
+
+func (s *adminServer) ResizeCanvas(ctx context.Context, req *pb.ResizeRequest) (*empty.Empty, error) {
+	width, height := int(req.GetWidth()), int(req.GetHeight())
+	if width < 1 || height < 1 || width > maxCanvasSize || height > maxCanvasSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Canvas size must be between 1x1 and %dx%d", maxCanvasSize, maxCanvasSize)
+	}
+	if _, ok := pb.ResizePolicy_name[int32(req.GetPolicy())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Unknown resize policy %d", req.GetPolicy())
+	}
+	policy := canvaspkg.ResizePolicy(req.GetPolicy())
+
+	// Hold the settings lock so watchers see the new size only once
+	settings.mut.Lock()
+	defer settings.mut.Unlock()
+	now := time.Now()
+	source := deltaSource(ctx)
+	resize := func() error {
+		publishMut.Lock()
+		defer publishMut.Unlock()
+		var err error
+		if history != nil {
+			err = history.Resize(canvas, width, height, policy, now)
+		} else {
+			err = canvas.Resize(width, height, policy)
+		}
+		if err != nil {
+			return err
+		}
+		if recorder != nil {
+			err := recorder.Append(&pb.DeltaRecord{Timestamp: now.UnixNano(), Source: source, Resize: req})
+			if err != nil {
+				log.Printf("Failed to record resize: %v", err)
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if delay != nil {
+		var discarded int
+		discarded, err = delay.resize(canvas, width, height, policy, resize)
+		if discarded > 0 {
+			log.Printf("Discarded %d pending deltas on resize", discarded)
+		}
+	} else {
+		err = resize()
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if detector != nil {
+		detector.Resize(width, height)
+	}
+
+	log.Printf("Canvas resized to %dx%d (%v) by %s", width, height, policy, source)
+	settings.notify()
+	return &empty.Empty{}, nil
+}
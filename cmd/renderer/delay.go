@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"image"
+	"log"
+	"sync"
+	"time"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type pendingDelta struct {
+	id       uint64
+	received time.Time
+	source   string
+	pixels   int
+	bounds   image.Rectangle
+	delta    *pb.NewDeltaImageRequest
+}
+
+//Holds deltas back from the public canvas for a moderation delay. The
+//moderator canvas shows the public canvas plus all pending deltas.
+type delayBuffer struct {
+	canvas   *canvaspkg.Canvas
+	producer *frame.Producer
+	delay    time.Duration
+	nextID   uint64
+	entries  []pendingDelta
+	mut      sync.Mutex
+}
+
+func newDelayBuffer(public *canvaspkg.Canvas, delay time.Duration) *delayBuffer {
+	return &delayBuffer{
+		canvas:  public.Clone(),
+		delay:   delay,
+		nextID:  1,
+		entries: make([]pendingDelta, 0),
+	}
+}
+
+//Apply a delta to the moderator canvas and queue it for the public canvas
+func (d *delayBuffer) push(received time.Time, source string, req *pb.NewDeltaImageRequest) error {
+	pixels := 0
+	bounds := image.Rectangle{}
+	width, height := d.canvas.Size()
+	_, _, err := utils.FilterDelta(req, width, height, func(x, y int, source string) bool {
+		pixels++
+		bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	err = utils.ApplyDelta(d.canvas, req)
+	if err != nil {
+		return err
+	}
+	d.entries = append(d.entries, pendingDelta{id: d.nextID, received: received, source: source, pixels: pixels, bounds: bounds, delta: req})
+	d.nextID++
+	promPendingDeltas.Set(float64(len(d.entries)))
+	return nil
+}
+
+//Publish deltas once their delay has passed. Publishing holds the lock, so a
+//delta is always either pending or on the public canvas when rebuilding.
+func (d *delayBuffer) run(publish func(source string, req *pb.NewDeltaImageRequest)) {
+	for {
+		d.mut.Lock()
+		now := time.Now()
+		n := 0
+		for n < len(d.entries) && !d.entries[n].received.Add(d.delay).After(now) {
+			publish(d.entries[n].source, d.entries[n].delta)
+			n++
+		}
+		d.entries = append(d.entries[:0], d.entries[n:]...)
+		promPendingDeltas.Set(float64(len(d.entries)))
+		wait := d.delay
+		if len(d.entries) > 0 {
+			wait = d.entries[0].received.Add(d.delay).Sub(now)
+		}
+		d.mut.Unlock()
+
+		time.Sleep(wait)
+	}
+}
+
+func (d *delayBuffer) pending() []pendingDelta {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return append([]pendingDelta{}, d.entries...)
+}
+
+//Discard the pending deltas with one of ids or from source, which is matched
+//by address or prefix like for DropSourceDeltas
+func (d *delayBuffer) discard(public *canvaspkg.Canvas, ids []uint64, source string) (int, error) {
+	drop := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		drop[id] = true
+	}
+	matches := func(string) bool { return false }
+	if source != "" {
+		matches = utils.SourceMatcher(source)
+	}
+
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	kept := make([]pendingDelta, 0, len(d.entries))
+	for _, e := range d.entries {
+		if !drop[e.id] && !matches(e.source) {
+			kept = append(kept, e)
+		}
+	}
+	discarded := len(d.entries) - len(kept)
+	if discarded == 0 {
+		return 0, nil
+	}
+	d.entries = kept
+	promPendingDeltas.Set(float64(len(d.entries)))
+	promDiscardedDeltas.Add(float64(discarded))
+	return discarded, d.rebuild(public)
+}
+
+//Reset the moderator canvas to the public canvas plus the pending deltas, must
+//be called with the lock held
+func (d *delayBuffer) rebuild(public *canvaspkg.Canvas) error {
+	rebuilt := public.Clone()
+	clockTime := time.Time{}
+	rebuilt.SetClock(func() time.Time { return clockTime })
+	for _, e := range d.entries {
+		clockTime = e.received
+		// Entries were valid when they were pushed
+		utils.ApplyDelta(rebuilt, e.delta)
+	}
+	return d.canvas.CopyPixels(rebuilt)
+}
+
+//Follow a change of the public canvas that did not come from this buffer
+func (d *delayBuffer) resync(public *canvaspkg.Canvas) error {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return d.rebuild(public)
+}
+
+//Select the frame producer of a canvas view
+func viewProducer(view pb.CanvasView) (*frame.Producer, error) {
+	switch view {
+	case pb.CanvasView_PUBLIC:
+		return producer, nil
+	case pb.CanvasView_MODERATOR:
+		if delay == nil {
+			return nil, status.Error(codes.FailedPrecondition, "Moderation delay is disabled")
+		}
+		return delay.producer, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "Unknown view %d", view)
+}
+
+func resyncModerator() {
+	if delay == nil {
+		return
+	}
+	err := delay.resync(canvas)
+	if err != nil {
+		log.Printf("Failed to resync moderator canvas: %v", err)
+	}
+}
+
+func (s *adminServer) ListPendingDeltas(ctx context.Context, req *empty.Empty) (*pb.PendingDeltas, error) {
+	if delay == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Moderation delay is disabled")
+	}
+	entries := delay.pending()
+	response := &pb.PendingDeltas{Deltas: make([]*pb.PendingDelta, len(entries))}
+	for i, e := range entries {
+		response.Deltas[i] = &pb.PendingDelta{
+			Id:        e.id,
+			Timestamp: e.received.UnixNano(),
+			PublishAt: e.received.Add(delay.delay).UnixNano(),
+			Source:    e.source,
+			Pixels:    uint64(e.pixels),
+			Bounds:    &pb.Rectangle{X: uint32(e.bounds.Min.X), Y: uint32(e.bounds.Min.Y), Width: uint32(e.bounds.Dx()), Height: uint32(e.bounds.Dy())},
+		}
+	}
+	return response, nil
+}
+
+func (s *adminServer) DiscardDeltas(ctx context.Context, req *pb.DiscardDeltasRequest) (*pb.DiscardDeltasResponse, error) {
+	if delay == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Moderation delay is disabled")
+	}
+	discarded, err := delay.discard(canvas, req.GetIds(), req.GetSource())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if discarded > 0 {
+		log.Printf("Discarded %d pending deltas", discarded)
+	}
+	return &pb.DiscardDeltasResponse{Discarded: uint64(discarded)}, nil
+}
+
+//Drop all pending deltas and clear the moderator canvas, clearPublic clears
+//the public canvas while no delta is being published
+func (d *delayBuffer) clear(clearPublic func()) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	clearPublic()
+	d.entries = d.entries[:0]
+	promPendingDeltas.Set(0)
+	d.canvas.Clear()
+}
+
+//Resize the moderator canvas, resizePublic resizes the public canvas while no
+//delta is being published. Pending deltas are discarded, they no longer fit.
+func (d *delayBuffer) resize(public *canvaspkg.Canvas, width int, height int, policy canvaspkg.ResizePolicy, resizePublic func() error) (int, error) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	err := resizePublic()
+	if err != nil {
+		return 0, err
+	}
+	discarded := len(d.entries)
+	d.entries = d.entries[:0]
+	promPendingDeltas.Set(0)
+	promDiscardedDeltas.Add(float64(discarded))
+	err = d.canvas.Resize(width, height, policy)
+	if err != nil {
+		return discarded, err
+	}
+	return discarded, d.rebuild(public)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+func pixelDelta(x uint32) *pb.NewDeltaImageRequest {
+	return &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: x, Y: 0, Rgb: 0xffffff}}}
+}
+
+//Report which pixels of the first row are set
+func setPixels(t *testing.T, c *canvaspkg.Canvas) []bool {
+	width, _ := c.Size()
+	set := make([]bool, width)
+	for x := range set {
+		info, err := c.GetPixelInfo(x, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		set[x] = info.R != 0
+	}
+	return set
+}
+
+func expectPixels(t *testing.T, name string, c *canvaspkg.Canvas, expected ...bool) {
+	t.Helper()
+	set := setPixels(t, c)
+	for x := range expected {
+		if set[x] != expected[x] {
+			t.Errorf("%s pixels %v, expected %v", name, set, expected)
+			return
+		}
+	}
+}
+
+func TestDelayPushAndRelease(t *testing.T) {
+	public := canvaspkg.NewCanvas(4, 1, uint64(time.Hour))
+	d := newDelayBuffer(public, 50*time.Millisecond)
+	err := d.push(time.Now(), "[2001:db8::1]:1000", pixelDelta(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.push(time.Now(), "", &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: 4, Y: 0}}})
+	if err == nil {
+		t.Errorf("Pushed a delta outside of the canvas")
+	}
+
+	pending := d.pending()
+	if len(pending) != 1 || pending[0].pixels != 1 || pending[0].bounds.Min.X != 1 {
+		t.Fatalf("Pending deltas %+v", pending)
+	}
+	expectPixels(t, "Moderator", d.canvas, false, true, false, false)
+	expectPixels(t, "Public", public, false, false, false, false)
+
+	published := make(chan string, 1)
+	go d.run(func(source string, req *pb.NewDeltaImageRequest) {
+		utils.ApplyDelta(public, req)
+		published <- source
+	})
+	select {
+	case source := <-published:
+		if source != "[2001:db8::1]:1000" {
+			t.Errorf("Published delta from %q", source)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Delta was not published")
+	}
+	expectPixels(t, "Public", public, false, true, false, false)
+	if len(d.pending()) != 0 {
+		t.Errorf("Published delta is still pending")
+	}
+}
+
+func TestDelayDiscard(t *testing.T) {
+	sources := []string{"[2001:db8::1]:1000", "[2001:db8::1]:2000", "[2001:db8::2]:1000", "192.0.2.1:1000"}
+	tests := []struct {
+		ids       []uint64
+		source    string
+		discarded int
+		kept      []bool
+	}{
+		{[]uint64{1}, "", 1, []bool{false, true, true, true}},
+		{nil, "[2001:db8::1]:1000", 2, []bool{false, false, true, true}},
+		{nil, "2001:db8::1", 2, []bool{false, false, true, true}},
+		{nil, "2001:db8::/64", 3, []bool{false, false, false, true}},
+		{[]uint64{4}, "2001:db8::2", 2, []bool{true, true, false, false}},
+		{nil, "198.51.100.0/24", 0, []bool{true, true, true, true}},
+	}
+	for _, test := range tests {
+		public := canvaspkg.NewCanvas(4, 1, uint64(time.Hour))
+		d := newDelayBuffer(public, time.Hour)
+		for x, source := range sources {
+			err := d.push(time.Now(), source, pixelDelta(uint32(x)))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		discarded, err := d.discard(public, test.ids, test.source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if discarded != test.discarded {
+			t.Errorf("Discarding %v and %q discarded %d deltas, expected %d", test.ids, test.source, discarded, test.discarded)
+		}
+		if len(d.pending()) != 4-test.discarded {
+			t.Errorf("Discarding %v and %q left %d deltas pending", test.ids, test.source, len(d.pending()))
+		}
+		expectPixels(t, "Moderator", d.canvas, test.kept...)
+	}
+}
+
+func TestDelayResizeAndClear(t *testing.T) {
+	public := canvaspkg.NewCanvas(4, 1, uint64(time.Hour))
+	utils.ApplyDelta(public, pixelDelta(0))
+	d := newDelayBuffer(public, time.Hour)
+	d.push(time.Now(), "", pixelDelta(1))
+
+	discarded, err := d.resize(public, 6, 1, canvaspkg.ResizeCrop, func() error {
+		return public.Resize(6, 1, canvaspkg.ResizeCrop)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if discarded != 1 || len(d.pending()) != 0 {
+		t.Errorf("Resize discarded %d deltas and left %d pending", discarded, len(d.pending()))
+	}
+	if width, _ := d.canvas.Size(); width != 6 {
+		t.Errorf("Moderator canvas is %d wide after the resize", width)
+	}
+	expectPixels(t, "Moderator", d.canvas, true, false, false, false, false, false)
+
+	d.push(time.Now(), "", pixelDelta(5))
+	cleared := false
+	d.clear(func() {
+		public.Clear()
+		cleared = true
+	})
+	if !cleared || len(d.pending()) != 0 {
+		t.Errorf("Clear left %d deltas pending", len(d.pending()))
+	}
+	expectPixels(t, "Moderator", d.canvas, false, false, false, false, false, false)
+	expectPixels(t, "Public", public, false, false, false, false, false, false)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sixelping/sixelping-renderer/pkg/flood"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+//Write rates are tracked in this many steps of the flood window
+const floodBuckets = 6
+
+//Source prefixes write rates are tracked for
+const floodPrefixBits = 64
+
+var detector *flood.Detector
+var promFloodAnomalies = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "renderer_flood_anomalies_total",
+	Help: "Total number of flagged flood anomalies",
+}, []string{"kind"})
+var promFloodFlagged = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "renderer_flood_flagged",
+	Help: "Number of currently flagged sources and regions",
+}, []string{"kind"})
+var promFloodSourceShare = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "renderer_flood_source_share",
+	Help: "Share of the canvas written within the flood window by flagged source prefixes",
+}, []string{"prefix"})
+var promFloodRegionRepaints = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "renderer_flood_region_repaints",
+	Help: "Number of times flagged regions were repainted within the flood window",
+}, []string{"region"})
+var promFloodPeak = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "renderer_flood_peak",
+	Help: "Highest source share and region repaints within the flood window",
+}, []string{"kind"})
+
+func setupFlood() {
+	if *floodWindowFlag <= 0 {
+		return
+	}
+	switch *floodActionFlag {
+	case "none", "throttle", "quarantine":
+	default:
+		log.Fatalf("Unknown flood action %q, expected one of none, throttle, quarantine", *floodActionFlag)
+	}
+
+	var err error
+	width, height := canvas.Size()
+	detector, err = flood.NewDetector(width, height, flood.Config{
+		Window:         *floodWindowFlag,
+		Buckets:        floodBuckets,
+		SourceShare:    *floodShareFlag,
+		RegionSize:     *floodRegionFlag,
+		RegionRepaints: *floodRepaintsFlag,
+	})
+	if err != nil {
+		log.Fatalf("Invalid flood detection: %v", err)
+	}
+	go floodSweeper()
+}
+
+//Count the pixels of a delta per source prefix and region, dropping those of
+//flagged sources when throttling. Quarantine drops the pixels of quarantined
+//sources and all pixels of deltas sent by one.
+func checkFlood(req *pb.NewDeltaImageRequest, sender string, width int, height int) (*pb.NewDeltaImageRequest, error) {
+	if detector == nil {
+		return req, nil
+	}
+	now := time.Now()
+	throttle := *floodActionFlag == "throttle"
+	quarantine := *floodActionFlag == "quarantine"
+	held := func(prefix string) bool {
+		return prefix != "" && ((throttle && detector.Flagged(prefix)) || (quarantine && detector.Quarantined(prefix, now)))
+	}
+	senderHeld := quarantine && held(utils.SourcePrefix(sender, floodPrefixBits))
+	prefixes := make(map[string]string)
+	flagged := make(map[string]bool)
+	sources := make(map[string]int)
+	regions := make(map[int]int)
+	regionIndex := detector.RegionIndexer()
+	filtered, dropped, err := utils.FilterDelta(req, width, height, func(x, y int, source string) bool {
+		prefix, ok := prefixes[source]
+		if !ok {
+			prefix = utils.SourcePrefix(source, floodPrefixBits)
+			prefixes[source] = prefix
+			flagged[prefix] = senderHeld || held(prefix)
+		}
+		if flagged[prefix] {
+			return false
+		}
+		if prefix != "" {
+			sources[prefix]++
+		}
+		regions[regionIndex(x, y)]++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dropped > 0 {
+		promPixelsDropped.WithLabelValues("flood").Add(float64(dropped))
+	}
+
+	for _, a := range detector.Observe(now, sources, regions) {
+		promFloodAnomalies.WithLabelValues(a.Kind.String()).Inc()
+		log.Printf("Flood detected: %s", describeAnomaly(a))
+		if a.Kind == flood.Source && quarantine {
+			// Quarantines are lifted once the flood clears, unlike blocks
+			detector.Quarantine(a.Key, now)
+			log.Printf("Quarantined %s", a.Key)
+		}
+	}
+	return filtered, nil
+}
+
+func describeAnomaly(a flood.Anomaly) string {
+	if a.Kind == flood.Source {
+		return fmt.Sprintf("%s wrote %.1f%% of the canvas within %v", a.Key, a.Value*100, *floodWindowFlag)
+	}
+	return fmt.Sprintf("region %v was repainted %.1f times within %v", a.Rect, a.Value, *floodWindowFlag)
+}
+
+//Periodically expire write rates and update the flood metrics
+func floodSweeper() {
+	for {
+		time.Sleep(*floodWindowFlag / floodBuckets)
+		for _, a := range detector.Sweep(time.Now()) {
+			log.Printf("Flood cleared: %s", describeAnomaly(a))
+		}
+
+		promFloodSourceShare.Reset()
+		promFloodRegionRepaints.Reset()
+		counts := map[flood.Kind]int{flood.Source: 0, flood.Region: 0}
+		for _, a := range detector.Anomalies() {
+			counts[a.Kind]++
+			if a.Kind == flood.Source {
+				promFloodSourceShare.WithLabelValues(a.Key).Set(a.Value)
+			} else {
+				promFloodRegionRepaints.WithLabelValues(a.Key).Set(a.Value)
+			}
+		}
+		for kind, n := range counts {
+			promFloodFlagged.WithLabelValues(kind.String()).Set(float64(n))
+		}
+
+		share, repaints := detector.Peak()
+		promFloodPeak.WithLabelValues(flood.Source.String()).Set(share)
+		promFloodPeak.WithLabelValues(flood.Region.String()).Set(repaints)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var healthServer = health.NewServer()
+
+//Unix nanoseconds of the last overlay loop iteration
+var overlayTick int64
+
+//Number of deltas being applied and unix nanoseconds of the last ingestion
+//progress, ingestion is stalled when deltas make no progress for too long
+var ingestInflight int64
+var ingestProgress int64
+
+var grpcServing int32
+
+func overlayAlive() {
+	atomic.StoreInt64(&overlayTick, time.Now().UnixNano())
+}
+
+//Track a delta being applied, the returned function marks it as done
+func ingestStarted() func() {
+	if atomic.AddInt64(&ingestInflight, 1) == 1 {
+		atomic.StoreInt64(&ingestProgress, time.Now().UnixNano())
+	}
+	return func() {
+		atomic.StoreInt64(&ingestProgress, time.Now().UnixNano())
+		atomic.AddInt64(&ingestInflight, -1)
+	}
+}
+
+//Return the failed liveness checks
+func livenessProblems(now time.Time) []string {
+	problems := make([]string, 0)
+	if since := now.Sub(producer.LastTick()); since > *healthTimeoutFlag {
+		problems = append(problems, fmt.Sprintf("render loop stalled for %v", since.Round(time.Millisecond)))
+	}
+	if delay != nil {
+		if since := now.Sub(delay.producer.LastTick()); since > *healthTimeoutFlag {
+			problems = append(problems, fmt.Sprintf("moderator render loop stalled for %v", since.Round(time.Millisecond)))
+		}
+	}
+	if since := now.Sub(time.Unix(0, atomic.LoadInt64(&overlayTick))); since > *healthTimeoutFlag {
+		problems = append(problems, fmt.Sprintf("overlay loop stalled for %v", since.Round(time.Millisecond)))
+	}
+	if atomic.LoadInt64(&ingestInflight) > 0 {
+		if since := now.Sub(time.Unix(0, atomic.LoadInt64(&ingestProgress))); since > *healthTimeoutFlag {
+			problems = append(problems, fmt.Sprintf("ingestion stalled for %v", since.Round(time.Millisecond)))
+		}
+	}
+	return problems
+}
+
+//Return the failed readiness checks, which include the liveness checks
+func readinessProblems(now time.Time) []string {
+	problems := livenessProblems(now)
+	if producer.Current() == nil {
+		problems = append(problems, "no frame rendered yet")
+	}
+	if atomic.LoadInt32(&grpcServing) == 0 {
+		problems = append(problems, "gRPC server not serving yet")
+	}
+	return problems
+}
+
+//Set the gRPC health status of all services from the readiness checks
+func updateHealth(now time.Time) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if len(readinessProblems(now)) > 0 {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range []string{"", "SixelpingRenderer", "SixelpingAdmin"} {
+		healthServer.SetServingStatus(service, status)
+	}
+}
+
+//Keep the gRPC health status in line with the readiness checks
+func healthUpdater() {
+	for {
+		updateHealth(time.Now())
+		time.Sleep(time.Second)
+	}
+}
+
+func healthHandler(checks func(now time.Time) []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		problems := checks(time.Now())
+		if len(problems) > 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, strings.Join(problems, "\n"))
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func healthStatus(t *testing.T, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.GetStatus()
+}
+
+func serveHealth(checks func(now time.Time) []string) (int, string) {
+	w := httptest.NewRecorder()
+	healthHandler(checks).ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+	return w.Code, w.Body.String()
+}
+
+func TestStalledRenderLoopFlipsReadiness(t *testing.T) {
+	stall := make(chan struct{})
+	rendered := make(chan struct{}, 1)
+	producer = frame.NewProducer(func(now time.Time) (*image.RGBA, error) {
+		select {
+		case rendered <- struct{}{}:
+		default:
+			// Every frame after the first stalls
+			<-stall
+		}
+		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+	}, 100)
+	defer func() {
+		close(stall)
+		producer = nil
+		atomic.StoreInt32(&grpcServing, 0)
+	}()
+
+	now := time.Now()
+	overlayAlive()
+	if problems := readinessProblems(now); len(problems) != 3 {
+		t.Errorf("Readiness problems before starting are %v", problems)
+	}
+	updateHealth(now)
+	if s := healthStatus(t, ""); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Health status before starting is %v", s)
+	}
+
+	go producer.Run()
+	<-rendered
+	for producer.Current() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	atomic.StoreInt32(&grpcServing, 1)
+	now = time.Now()
+	if problems := readinessProblems(now); len(problems) != 0 {
+		t.Errorf("Readiness problems while serving are %v", problems)
+	}
+	updateHealth(now)
+	for _, service := range []string{"", "SixelpingRenderer", "SixelpingAdmin"} {
+		if s := healthStatus(t, service); s != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Health status of %q while serving is %v", service, s)
+		}
+	}
+	if code, body := serveHealth(readinessProblems); code != http.StatusOK || body != "ok\n" {
+		t.Errorf("Readiness endpoint returned %d %q while serving", code, body)
+	}
+
+	// Only the render loop stalls, the overlay loop keeps running
+	later := now.Add(*healthTimeoutFlag + time.Second)
+	atomic.StoreInt64(&overlayTick, later.UnixNano())
+	problems := readinessProblems(later)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "render loop stalled") {
+		t.Errorf("Readiness problems of a stalled render loop are %v", problems)
+	}
+	if len(livenessProblems(later)) != 1 {
+		t.Errorf("Liveness problems of a stalled render loop are %v", livenessProblems(later))
+	}
+	updateHealth(later)
+	if s := healthStatus(t, "SixelpingRenderer"); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Health status of a stalled render loop is %v", s)
+	}
+	code, body := serveHealth(func(time.Time) []string { return readinessProblems(later) })
+	if code != http.StatusServiceUnavailable || !strings.HasPrefix(body, "render loop stalled") {
+		t.Errorf("Readiness endpoint returned %d %q for a stalled render loop", code, body)
+	}
+}
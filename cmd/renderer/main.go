@@ -9,12 +9,15 @@ import (
 	"image/color"
 	"image/draw"
 	"image/png"
+	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"runtime/pprof"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -27,33 +30,97 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/model"
 	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	"github.com/sixelping/sixelping-renderer/pkg/deltalog"
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+	historypkg "github.com/sixelping/sixelping-renderer/pkg/history"
+	"github.com/sixelping/sixelping-renderer/pkg/moderation"
+	"github.com/sixelping/sixelping-renderer/pkg/rawstream"
 	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
 	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 var canvas *canvaspkg.Canvas
+var producer *frame.Producer
+var recorder *deltalog.Writer
+
+//Serializes changes to the public canvas together with recording them, so the
+//delta log holds them in the order they were applied
+var publishMut sync.Mutex
+var history *historypkg.History
+var moderator *moderation.Moderator
+var delay *delayBuffer
 var widthFlag = flag.Int("width", 1920, "Canvas Width")
 var heightFlag = flag.Int("height", 1080, "Canvas Height")
 var fpsFlag = flag.Int("fps", 1, "Canvas FPS")
 var pixTimeoutFlag = flag.Float64("pixeltime", 1.0, "Canvas pixel timeout in seconds")
+var fadeFlag = flag.String("fade", "linear", "Pixel fade curve (linear, exponential, step, easeout, persistent, desaturate)")
 var listenFlag = flag.String("listen", ":50051", "Listen address")
+var adminListenFlag = flag.String("adminlisten", "127.0.0.1:50053", "Admin service listen address, the admin service is unauthenticated so it defaults to loopback, empty to disable")
+var adminSharedFlag = flag.Bool("adminshared", false, "Serve the admin service on the listen address instead, anyone reaching it can control the canvas")
 var promListenFlag = flag.String("mlisten", ":50052", "Metrics listen address")
 var logoFlag = flag.String("logo", "", "Logo file")
+var deltaQueueFlag = flag.Int("deltaqueue", 16, "Number of deltas buffered per delta stream")
+var ackIntervalFlag = flag.Duration("ackinterval", time.Second, "Interval between delta stream acknowledgements")
+var tileSizeFlag = flag.Int("tilesize", 64, "Tile size for GetRenderedTiles, 0 disables tile tracking")
+var tileHistoryFlag = flag.Int("tilehistory", 300, "Number of frames GetRenderedTiles can compute changes against, 0 disables tile tracking")
+var rawListenFlag = flag.String("rawlisten", ":12345", "Raw frame output listen address, empty to disable")
+var rawFormatFlag = flag.String("rawformat", "rgb24", "Raw frame pixel format (rgb24, rgba, bgra, rgb565, yuv420p)")
+var rawCompressionFlag = flag.String("rawcompression", "none", "Raw frame compression (none, lz4, zstd)")
+var rawHeaderFlag = flag.Bool("rawheader", false, "Prefix every raw frame with a header, off by default for existing headerless RGB24 consumers")
 var promServerFlag = flag.String("prom", "", "Prometheus endpoint to query")
 var promDeltasReceived = promauto.NewCounter(prometheus.CounterOpts{
 	Name: "renderer_deltas_received_total",
 	Help: "Total number of received deltas",
 })
+var promPixelsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "renderer_pixels_dropped_total",
+	Help: "Total number of pixels dropped before reaching the canvas",
+}, []string{"reason"})
+var promPendingDeltas = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "renderer_pending_deltas",
+	Help: "Number of deltas waiting for the moderation delay",
+})
+var promDiscardedDeltas = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "renderer_deltas_discarded_total",
+	Help: "Total number of pending deltas discarded by moderators",
+})
 var promPacketsReceived *ReceiverMetric
 var promPacketsSent *ReceiverMetric
 var promPacketsDropped *ReceiverMetric
 var promBytesReceived *ReceiverMetric
 var promBytesSent *ReceiverMetric
 var promPingsReceived *ReceiverPerClientMetric
+var snapshotFlag = flag.String("snapshot", "", "Canvas snapshot file, empty to disable snapshots")
+var snapshotIntervalFlag = flag.Duration("snapshotinterval", time.Minute, "Interval between canvas snapshots")
+var restoreFlag = flag.Bool("restore", false, "Restore the canvas from the snapshot file at startup")
+var recordFlag = flag.String("record", "", "Directory to record applied deltas to, empty to disable")
+var recordSizeFlag = flag.Int64("recordsize", 256, "Maximum size of a delta log segment in MB, 0 for no limit")
+var recordAgeFlag = flag.Duration("recordage", time.Hour, "Maximum age of a delta log segment, 0 for no limit")
+var historyFlag = flag.Duration("history", 0, "How long applied deltas are retained for rollbacks, 0 to disable")
+var historySizeFlag = flag.Int("historysize", 100000, "Maximum number of deltas retained for rollbacks, 0 for no limit")
+var historyBytesFlag = flag.Int64("historybytes", 1024, "Maximum size of the deltas retained for rollbacks in MB, 0 for no limit")
+var moderationFlag = flag.String("moderation", "", "File masks and source blocklists are persisted to, empty to keep them in memory")
+var modDelayFlag = flag.Duration("moddelay", 0, "Delay before deltas reach the public canvas, during which moderators can discard them, 0 to disable")
+var floodWindowFlag = flag.Duration("floodwindow", 0, "Window flood detection computes write rates over, e.g. 1m, 0 to disable")
+var floodShareFlag = flag.Float64("floodshare", 0.05, "Flag a /64 writing more than this fraction of the canvas within the flood window")
+var floodRegionFlag = flag.Int("floodregion", 64, "Edge length of the regions flood detection tracks")
+var floodRepaintsFlag = flag.Float64("floodrepaints", 20, "Flag a region repainted more than this many times within the flood window")
+var floodActionFlag = flag.String("floodaction", "none", "Action against flagged sources (none, throttle, quarantine), quarantine also drops deltas sent by flagged sources and lasts until the flood clears, at most the flood window")
+var quota128Flag = flag.String("quota128", "", "Pixel quota per /128 source as pixels/window, e.g. 10/10s, empty for no limit")
+var quota64Flag = flag.String("quota64", "", "Pixel quota per /64 source as pixels/window, empty for no limit")
+var quota48Flag = flag.String("quota48", "", "Pixel quota per /48 source as pixels/window, empty for no limit")
+var healthTimeoutFlag = flag.Duration("healthtimeout", 30*time.Second, "How long the render, overlay and ingestion loops may stall before the renderer reports itself unhealthy")
+var receiverTimeoutFlag = flag.Duration("receivertimeout", 30*time.Second, "Time without heartbeats or deltas after which a receiver is stale, stale receivers are forgotten after 10 times this")
+var mappingsFlag = flag.String("mappings", "", "File receiver mappings are persisted to, empty to keep them in memory")
 var cpuprofile = flag.String("cpuprofile", "", "write cpu profile to file")
 
 // server is used to implement helloworld.GreeterServer.
@@ -62,21 +129,259 @@ type server struct {
 }
 
 func (s *server) NewDeltaImage(ctx context.Context, req *pb.NewDeltaImageRequest) (*empty.Empty, error) {
-	err := canvas.AddDelta(req.GetImage())
+	err := applyDelta(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	return &empty.Empty{}, nil
+}
+
+//Receive deltas continuously, acknowledging them every ackinterval
+func (s *server) StreamDeltas(stream pb.SixelpingRenderer_StreamDeltasServer) error {
+	queue := make(chan *pb.NewDeltaImageRequest, *deltaQueueFlag)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(queue)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					recvErr <- err
+				}
+				return
+			}
+
+			// Blocks while the queue is full, which stalls the stream's flow control
+			select {
+			case queue <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	ack := &pb.DeltaAck{}
+	ticker := time.NewTicker(*ackIntervalFlag)
+	defer ticker.Stop()
+	for {
+		select {
+		case req, ok := <-queue:
+			if !ok {
+				select {
+				case err := <-recvErr:
+					return err
+				default:
+				}
+				return stream.Send(ack)
+			}
+
+			err := applyDelta(stream.Context(), req)
+			if err != nil {
+				ack.Rejected++
+				ack.LastError = status.Convert(err).Message()
+			} else {
+				ack.Applied++
+			}
+		case <-ticker.C:
+			ack.Queued = uint32(len(queue))
+			ack.Throttle = len(queue) >= cap(queue)*3/4
+			err := stream.Send(ack)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//Apply a delta request to the canvas, or queue it for the moderation delay
+func applyDelta(ctx context.Context, req *pb.NewDeltaImageRequest) error {
+	if settings.Paused() {
+		return status.Error(codes.Unavailable, "Ingestion is paused")
+	}
+	defer ingestStarted()()
+	received := time.Now()
+	source := deltaSource(ctx)
+	width, height := canvas.Size()
+	req, err := mapDelta(req, source, width, height)
+	if err != nil {
+		return err
+	}
+	req, err = moderate(req, source, width, height)
+	if err != nil {
+		return deltaError(err)
+	}
+	req, err = checkFlood(req, source, width, height)
+	if err != nil {
+		return deltaError(err)
+	}
+	req, err = checkQuota(req, width, height)
+	if err != nil {
+		return deltaError(err)
+	}
+	if delay != nil {
+		err = delay.push(received, source, req)
+	} else {
+		err = publishDelta(received, source, req)
+	}
+	if err != nil {
+		return deltaError(err)
+	}
 
 	promDeltasReceived.Inc()
-	return &empty.Empty{}, nil
+	if req.GetReceiverId() != "" {
+		receivers.delta(req.GetReceiverId())
+	}
+	return nil
+}
+
+//Apply a delta to the public canvas and record it
+func publishDelta(published time.Time, source string, req *pb.NewDeltaImageRequest) error {
+	publishMut.Lock()
+	defer publishMut.Unlock()
+	var err error
+	if history != nil {
+		err = history.Apply(canvas, published, source, req)
+	} else {
+		err = utils.ApplyDelta(canvas, req)
+	}
+	if err != nil {
+		return err
+	}
+
+	if recorder != nil {
+		err := recorder.Append(&pb.DeltaRecord{Timestamp: published.UnixNano(), Source: source, Delta: req})
+		if err != nil {
+			log.Printf("Failed to record delta: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *adminServer) RollbackCanvas(ctx context.Context, req *pb.RollbackCanvasRequest) (*pb.RollbackResponse, error) {
+	if history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Delta history is disabled")
+	}
+	now := time.Now()
+	source := deltaSource(ctx)
+	publishMut.Lock()
+	response, err := history.Rollback(canvas, req.GetTimestamp(), now)
+	if err == nil && recorder != nil {
+		err := recorder.Append(&pb.DeltaRecord{Timestamp: now.UnixNano(), Source: source, Rollback: req})
+		if err != nil {
+			log.Printf("Failed to record rollback: %v", err)
+		}
+	}
+	publishMut.Unlock()
+	if err != nil {
+		return nil, historyError(err)
+	}
+	log.Printf("Rolled canvas back to %v, dropped %d deltas", time.Unix(0, req.GetTimestamp()), response.GetDropped())
+	resyncModerator()
+	return response, nil
+}
+
+func (s *adminServer) DropSourceDeltas(ctx context.Context, req *pb.DropSourceDeltasRequest) (*pb.RollbackResponse, error) {
+	if history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Delta history is disabled")
+	}
+	now := time.Now()
+	to := req.GetTo()
+	if to == 0 {
+		to = now.UnixNano()
+	}
+	publishMut.Lock()
+	response, err := history.DropSource(canvas, req.GetSource(), req.GetFrom(), to, now)
+	if err == nil && recorder != nil {
+		// Record where the removal started, a replay may retain more deltas
+		from := req.GetFrom()
+		if from < response.GetOldest() {
+			from = response.GetOldest()
+		}
+		drop := &pb.DropSourceDeltasRequest{Source: req.GetSource(), From: from, To: to}
+		err := recorder.Append(&pb.DeltaRecord{Timestamp: now.UnixNano(), Source: deltaSource(ctx), DropSource: drop})
+		if err != nil {
+			log.Printf("Failed to record source drop: %v", err)
+		}
+	}
+	publishMut.Unlock()
+	if err != nil {
+		return nil, historyError(err)
+	}
+	log.Printf("Dropped %d deltas from %s", response.GetDropped(), req.GetSource())
+	resyncModerator()
+	return response, nil
+}
+
+func (s *server) GetPixelInfo(ctx context.Context, req *pb.PixelInfoRequest) (*pb.PixelInfoResponse, error) {
+	info, err := canvas.GetPixelInfo(int(req.GetX()), int(req.GetY()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	response := &pb.PixelInfoResponse{
+		Rgb:    uint32(info.R)<<16 | uint32(info.G)<<8 | uint32(info.B),
+		Source: info.Source,
+	}
+	if !info.LastUpdated.IsZero() {
+		response.LastUpdated = info.LastUpdated.UnixNano()
+		response.Age = int64(time.Since(info.LastUpdated))
+	}
+	return response, nil
+}
+
+func historyError(err error) error {
+	if errors.Is(err, historypkg.ErrBeforeHistory) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+//Identify where a delta came from
+func deltaSource(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+//Convert a rectangle message, the far edges are computed as int so they
+//cannot wrap around
+func rectangle(r *pb.Rectangle) image.Rectangle {
+	return image.Rect(int(r.GetX()), int(r.GetY()), int(r.GetX())+int(r.GetWidth()), int(r.GetY())+int(r.GetHeight()))
+}
+
+//Map canvas errors to gRPC status errors
+func deltaError(err error) error {
+	if errors.Is(err, canvaspkg.ErrInvalidDelta) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
 }
 
 //Inform others about the canvas parameters
 func (s *server) GetCanvasParameters(ctx context.Context, req *empty.Empty) (*pb.CanvasParametersResponse, error) {
-	return &pb.CanvasParametersResponse{Width: uint32(*widthFlag), Height: uint32(*heightFlag), Fps: uint32(*fpsFlag)}, nil
+	return settings.parameters(), nil
+}
+
+//Stream the canvas parameters and every change to them
+func (s *server) WatchCanvasParameters(req *empty.Empty, stream pb.SixelpingRenderer_WatchCanvasParametersServer) error {
+	c := settings.Watch()
+	defer settings.Unwatch(c)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case parameters := <-c:
+			err := stream.Send(parameters)
+			if err != nil {
+				return err
+			}
+		}
+	}
 }
 
 func (s *server) MetricsUpdate(ctx context.Context, req *pb.MetricsDatapoint) (*empty.Empty, error) {
+	receivers.seenMac(req.GetMac())
 	promPacketsReceived.Set(req.GetMac(), req.GetIpackets())
 	promPacketsSent.Set(req.GetMac(), req.GetOpackets())
 	promPacketsDropped.Set(req.GetMac(), req.GetDpackets())
@@ -88,43 +393,173 @@ func (s *server) MetricsUpdate(ctx context.Context, req *pb.MetricsDatapoint) (*
 	return &empty.Empty{}, nil
 }
 
-func (s *server) GetRenderedImage(ctx context.Context, req *empty.Empty) (*pb.RenderedImageResponse, error) {
-	img, err := canvas.GetImage(time.Now())
+func (s *server) GetRenderedImage(ctx context.Context, req *pb.RenderedImageRequest) (*pb.RenderedImageResponse, error) {
+	producer, err := viewProducer(req.GetView())
 	if err != nil {
 		return nil, err
 	}
-	return &pb.RenderedImageResponse{Image: utils.ImageToBytes(img)}, nil
+	f := producer.Current()
+	if f == nil {
+		return nil, status.Error(codes.Unavailable, "No frame rendered yet")
+	}
+
+	opts := frame.Options{
+		Encoding: frame.Encoding(req.GetEncoding()),
+		Quality:  int(req.GetQuality()),
+		Scale:    float64(req.GetScale()),
+	}
+	if crop := req.GetCrop(); crop != nil {
+		opts.Crop = rectangle(crop)
+	}
+
+	img, size, err := f.Render(opts)
+	if err != nil {
+		if errors.Is(err, frame.ErrInvalidOptions) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RenderedImageResponse{
+		Image:       img,
+		FrameNumber: f.Number,
+		Timestamp:   f.Time.UnixNano(),
+		Encoding:    req.GetEncoding(),
+		Width:       uint32(size.X),
+		Height:      uint32(size.Y),
+	}, nil
 }
 
-func handleTcp(conn net.Conn) {
-	psd := time.Second / time.Duration(int64(*fpsFlag))
-	nextTime := time.Now()
-	for {
-		img, err := canvas.GetImage(time.Now())
+func (s *server) GetRenderedTiles(ctx context.Context, req *pb.RenderedTilesRequest) (*pb.RenderedTilesResponse, error) {
+	producer, err := viewProducer(req.GetView())
+	if err != nil {
+		return nil, err
+	}
+	f, tiles, full, err := producer.ChangedTiles(req.GetSinceFrame())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if f == nil {
+		return nil, status.Error(codes.Unavailable, "No frame rendered yet")
+	}
+
+	response := &pb.RenderedTilesResponse{
+		FrameNumber: f.Number,
+		Timestamp:   f.Time.UnixNano(),
+		Width:       uint32(f.Image.Rect.Dx()),
+		Height:      uint32(f.Image.Rect.Dy()),
+		Full:        full,
+		Encoding:    req.GetEncoding(),
+		Tiles:       make([]*pb.RenderedTile, len(tiles)),
+	}
+	for i, t := range tiles {
+		img, _, err := f.Render(frame.Options{Encoding: frame.Encoding(req.GetEncoding()), Crop: t})
 		if err != nil {
-			log.Printf("Error transmitting: %v", err)
-			return
+			if errors.Is(err, frame.ErrInvalidOptions) {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Tiles[i] = &pb.RenderedTile{
+			Rect:  &pb.Rectangle{X: uint32(t.Min.X), Y: uint32(t.Min.Y), Width: uint32(t.Dx()), Height: uint32(t.Dy())},
+			Image: img,
 		}
+	}
+	return response, nil
+}
+
+func (s *server) StreamRenderedImages(req *pb.StreamRenderedImagesRequest, stream pb.SixelpingRenderer_StreamRenderedImagesServer) error {
+	if _, ok := pb.ImageEncoding_name[int32(req.GetEncoding())]; !ok {
+		return status.Errorf(codes.InvalidArgument, "Unknown encoding %d", req.GetEncoding())
+	}
 
-		buf := make([]byte, (*widthFlag)*(*heightFlag)*3)
-		bufI := 0
-		for i := 0; i < len(img.Pix); i++ {
-			if (i % 4) != 3 {
-				buf[bufI] = img.Pix[i]
-				bufI++
+	minInterval := time.Duration(0)
+	if req.GetMaxFps() > 0 {
+		minInterval = time.Second / time.Duration(int64(req.GetMaxFps()))
+	}
+
+	producer, err := viewProducer(req.GetView())
+	if err != nil {
+		return err
+	}
+	frames := producer.Subscribe()
+	defer producer.Unsubscribe(frames)
+
+	//Pace on the time frames are sent rather than rendered, a frozen display
+	//publishes the same frame again and again. Frames arriving slightly early
+	//are sent, the schedule keeps the average rate below max_fps.
+	var nextSend time.Time
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case f := <-frames:
+			if minInterval > 0 {
+				now := time.Now()
+				if now.Before(nextSend.Add(-minInterval / 8)) {
+					continue
+				}
+				nextSend = nextSend.Add(minInterval)
+				if nextSend.Before(now) {
+					nextSend = now.Add(minInterval)
+				}
 			}
-		}
 
-		conn.Write(buf)
+			err := stream.Send(renderedImageResponse(f, req.GetEncoding()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
 
-		nextTime = nextTime.Add(psd)
-		time.Sleep(time.Until(nextTime))
+//Build a response from a shared frame, the encoding is cached on the frame
+func renderedImageResponse(f *frame.Frame, encoding pb.ImageEncoding) *pb.RenderedImageResponse {
+	return &pb.RenderedImageResponse{
+		Image:       f.Bytes(frame.Encoding(encoding)),
+		FrameNumber: f.Number,
+		Timestamp:   f.Time.UnixNano(),
+		Encoding:    encoding,
+		Width:       uint32(f.Image.Rect.Dx()),
+		Height:      uint32(f.Image.Rect.Dy()),
 	}
+}
+
+func handleTcp(conn net.Conn, encoder *rawstream.Encoder) {
+	defer conn.Close()
+	frames := producer.Subscribe()
+	defer producer.Unsubscribe(frames)
 
+	for f := range frames {
+		packet, err := encoder.Packet(f)
+		if err != nil {
+			log.Printf("Error encoding raw frame: %v", err)
+			return
+		}
+
+		_, err = conn.Write(packet)
+		if err != nil {
+			log.Printf("Error transmitting to %v: %v", conn.RemoteAddr(), err)
+			return
+		}
+	}
 }
 
 func tcpListener() error {
-	l, err := net.Listen("tcp", ":12345")
+	format, err := rawstream.ParseFormat(*rawFormatFlag)
+	if err != nil {
+		return err
+	}
+	compression, err := rawstream.ParseCompression(*rawCompressionFlag)
+	if err != nil {
+		return err
+	}
+	encoder, err := rawstream.NewEncoder(format, compression, *rawHeaderFlag)
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", *rawListenFlag)
 	if err != nil {
 		return err
 	}
@@ -137,7 +572,7 @@ func tcpListener() error {
 			return err
 		}
 
-		go handleTcp(conn)
+		go handleTcp(conn, encoder)
 	}
 }
 
@@ -163,13 +598,8 @@ func queryPrometheus(q string, client api.Client) (float64, error) {
 }
 
 func overlayer() {
-	var logoImage image.Image
 	var client api.Client
-	logoImage = nil
 	client = nil
-	if *logoFlag != "" {
-		logoImage = readLogo()
-	}
 
 	if *promServerFlag != "" {
 		var err error
@@ -182,15 +612,18 @@ func overlayer() {
 	}
 
 	for {
-		overlay := image.NewRGBA(image.Rect(0, 0, *widthFlag, *heightFlag))
-		for y := 0; y < *heightFlag; y++ {
-			for x := 0; x < *widthFlag; x++ {
+		overlayAlive()
+		logoImage := settings.Logo()
+		width, height := canvas.Size()
+		overlay := image.NewRGBA(image.Rect(0, 0, width, height))
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
 				overlay.Set(x, y, image.Transparent)
 			}
 		}
 
 		if logoImage != nil {
-			draw.Draw(overlay, logoImage.Bounds().Add(image.Point{10, *heightFlag - (logoImage.Bounds().Max.Y + 10)}), logoImage, image.ZP, draw.Over)
+			draw.Draw(overlay, logoImage.Bounds().Add(image.Point{10, height - (logoImage.Bounds().Max.Y + 10)}), logoImage, image.ZP, draw.Over)
 		}
 
 		if client != nil {
@@ -211,7 +644,7 @@ func overlayer() {
 			}
 
 			x := 10
-			y := *heightFlag - 10
+			y := height - 10
 
 			if logoImage != nil {
 				x = x + logoImage.Bounds().Max.X + 10
@@ -223,7 +656,7 @@ func overlayer() {
 				col = color.RGBA{255, 0, 0, 255}
 				text = fmt.Sprintf("%s | Sixelping is currently overloaded and is dropping some of your pings", text)
 			}
-			point := fixed.Point26_6{fixed.Int26_6(x * 64), fixed.Int26_6(y * 64)}
+			point := fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)}
 
 			d := &font.Drawer{
 				Dst:  overlay,
@@ -235,6 +668,9 @@ func overlayer() {
 		}
 
 		canvas.SetOverlayImage(overlay)
+		if delay != nil {
+			delay.canvas.SetOverlayImage(overlay)
+		}
 		time.Sleep(time.Second)
 	}
 }
@@ -257,8 +693,78 @@ func readLogo() image.Image {
 }
 
 func setupCanvas() {
-	canvas = canvaspkg.NewCanvas(*widthFlag, *heightFlag, uint64((*pixTimeoutFlag)*1000000000))
+	fade, err := canvaspkg.ParseFadeFunc(*fadeFlag)
+	if err != nil {
+		log.Fatalf("Invalid fade: %v", err)
+	}
+	canvas = canvaspkg.NewCanvas(*widthFlag, *heightFlag, settings.pixelTimeout)
+	canvas.SetFadeFunc(fade)
+	if *restoreFlag && *snapshotFlag != "" {
+		err := restoreSnapshot()
+		if err != nil {
+			log.Printf("Failed to restore snapshot: %v", err)
+		} else {
+			width, height := canvas.Size()
+			log.Printf("Restored %dx%d canvas from %s", width, height, *snapshotFlag)
+		}
+	}
+	if *snapshotFlag != "" {
+		go snapshotter()
+	}
+	if *historyFlag > 0 {
+		history = historypkg.New(canvas, time.Now(), *historyFlag, *historySizeFlag, (*historyBytesFlag)*1000000)
+	}
+	producer = newProducer(canvas)
+	if *modDelayFlag > 0 {
+		delay = newDelayBuffer(canvas, *modDelayFlag)
+		delay.producer = newProducer(delay.canvas)
+		go delay.producer.Run()
+		go delay.run(func(source string, req *pb.NewDeltaImageRequest) {
+			err := publishDelta(time.Now(), source, req)
+			if err != nil {
+				log.Printf("Failed to publish delta: %v", err)
+			}
+		})
+	}
 	go overlayer()
+	go producer.Run()
+}
+
+func newProducer(c *canvaspkg.Canvas) *frame.Producer {
+	p := frame.NewProducer(c.GetImage, settings.Fps())
+	if *tileSizeFlag > 0 && *tileHistoryFlag > 0 {
+		p.EnableTiles(*tileSizeFlag, *tileHistoryFlag)
+	}
+	return p
+}
+
+func setupRecorder() {
+	if *recordFlag == "" {
+		return
+	}
+	var err error
+	recorder, err = deltalog.NewWriter(*recordFlag, (*recordSizeFlag)*1000000, *recordAgeFlag, canvas.Size)
+	if err != nil {
+		log.Fatalf("Failed to open delta log: %v", err)
+	}
+}
+
+func setupModeration() {
+	var err error
+	moderator, err = moderation.New(*moderationFlag)
+	if err != nil {
+		log.Fatalf("Failed to load moderation state: %v", err)
+	}
+}
+
+//Remove the metrics of an evicted receiver
+func forgetMac(mac string) {
+	promPacketsReceived.Delete(mac)
+	promPacketsSent.Delete(mac)
+	promPacketsDropped.Delete(mac)
+	promBytesReceived.Delete(mac)
+	promBytesSent.Delete(mac)
+	promPingsReceived.Delete(mac)
 }
 
 func setupMetrics() {
@@ -268,6 +774,7 @@ func setupMetrics() {
 	promBytesReceived = NewReceiverMetric("receiver_bytes_received", "Number of received bytes")
 	promBytesSent = NewReceiverMetric("receiver_bytes_sent", "Number of sent bytes")
 	promPingsReceived = NewReceiverPerClientMetric("receiver_pings_received_per_client", "Pings received per client")
+	receivers = newReceiverRegistry(*receiverTimeoutFlag)
 
 	prometheus.Register(promPacketsReceived)
 	prometheus.Register(promPacketsSent)
@@ -275,6 +782,8 @@ func setupMetrics() {
 	prometheus.Register(promBytesSent)
 	prometheus.Register(promBytesReceived)
 	prometheus.Register(promPingsReceived)
+	prometheus.Register(receivers)
+	go receivers.run(10 * time.Second)
 
 }
 
@@ -284,6 +793,18 @@ func setupCloseHandler() {
 	go func() {
 		<-c
 		fmt.Println("\r- Ctrl+C pressed in Terminal")
+		if recorder != nil {
+			err := recorder.Close()
+			if err != nil {
+				log.Printf("Failed to close delta log: %v", err)
+			}
+		}
+		if *snapshotFlag != "" {
+			err := saveSnapshot()
+			if err != nil {
+				log.Printf("Failed to save snapshot: %v", err)
+			}
+		}
 		pprof.StopCPUProfile()
 		os.Exit(0)
 	}()
@@ -297,12 +818,22 @@ func main() {
 			log.Fatal(err)
 		}
 		pprof.StartCPUProfile(f)
-		setupCloseHandler()
 		defer pprof.StopCPUProfile()
 	}
+	setupCloseHandler()
 	setupMetrics()
+	setupSettings()
+	setupModeration()
+	setupMappings()
 	setupCanvas()
-	go tcpListener()
+	setupQuota()
+	setupFlood()
+	setupRecorder()
+	if *rawListenFlag != "" {
+		go func() {
+			log.Fatalf("Raw output failed: %v", tcpListener())
+		}()
+	}
 	lis, err := net.Listen("tcp", *listenFlag)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -314,15 +845,27 @@ func main() {
 		grpc.MaxSendMsgSize(64000000),
 	)
 	pb.RegisterSixelpingRendererServer(s, &server{})
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+	if *adminSharedFlag {
+		log.Println("Serving the admin service on the listen address")
+		pb.RegisterSixelpingAdminServer(s, &adminServer{})
+	} else if *adminListenFlag != "" {
+		go serveAdmin()
+	}
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(s)
 	log.Println("Serving requests...")
 
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/healthz", healthHandler(livenessProblems))
+		http.Handle("/readyz", healthHandler(readinessProblems))
 		http.ListenAndServe(*promListenFlag, nil)
 	}()
+	go healthUpdater()
 
+	atomic.StoreInt32(&grpcServing, 1)
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeltaErrorInvalidSize(t *testing.T) {
+	c := canvaspkg.NewCanvas(4, 4, 1000000000)
+	tests := []struct {
+		name string
+		req  *pb.NewDeltaImageRequest
+		code codes.Code
+	}{
+		{"valid", &pb.NewDeltaImageRequest{Image: make([]byte, 4*4*3), Format: pb.PixelFormat_RGB24}, codes.OK},
+		{"short image", &pb.NewDeltaImageRequest{Image: make([]byte, 4*4*3-1), Format: pb.PixelFormat_RGB24}, codes.InvalidArgument},
+		{"wrong format", &pb.NewDeltaImageRequest{Image: make([]byte, 4*4*3), Format: pb.PixelFormat_BGRA}, codes.InvalidArgument},
+		{"pixel outside", &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: 4, Y: 0}}}, codes.InvalidArgument},
+		{"unknown source", &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: 0, Y: 0, Source: 1}}}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := utils.ApplyDelta(c, test.req)
+			if err != nil {
+				err = deltaError(err)
+			}
+			if code := status.Code(err); code != test.code {
+				t.Errorf("Got %v (%v), want %v", code, err, test.code)
+			}
+		})
+	}
+}
+
+func TestRectangleDoesNotWrap(t *testing.T) {
+	r := rectangle(&pb.Rectangle{X: 10, Y: 20, Width: 0xfffffff6, Height: 0xffffffec})
+	if int64(r.Max.X) != 1<<32 || int64(r.Max.Y) != 1<<32 {
+		t.Errorf("Rectangle wrapped around to %v", r)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"image"
+	"log"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/sixelping/sixelping-renderer/pkg/mapping"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var mappings *mapping.Store
+
+func setupMappings() {
+	var err error
+	mappings, err = mapping.NewStore(*mappingsFlag)
+	if err != nil {
+		log.Fatalf("Failed to load receiver mappings: %v", err)
+	}
+}
+
+//Translate a delta of a mapped receiver to canvas coordinates. Receiver ids
+//are chosen by the client, so a mapped receiver's deltas are only accepted from
+//its peer. Without any mappings deltas are drawn on the canvas as is, once a
+//receiver is mapped deltas of unmapped receivers are rejected.
+func mapDelta(req *pb.NewDeltaImageRequest, sender string, width int, height int) (*pb.NewDeltaImageRequest, error) {
+	m, ok := mappings.Get(req.GetReceiverId())
+	if !ok {
+		if mappings.Len() > 0 {
+			return nil, status.Errorf(codes.PermissionDenied, "Receiver %q is not mapped", req.GetReceiverId())
+		}
+		return req, nil
+	}
+	if !m.Allows(sender) {
+		return nil, status.Errorf(codes.PermissionDenied, "Receiver %q is not mapped for %s", m.ReceiverID, sender)
+	}
+	mapped, err := m.Map(req, width, height)
+	if err != nil {
+		return nil, deltaError(err)
+	}
+	return mapped, nil
+}
+
+//Size of the canvas a receiver draws on
+func receiverSize(id string) (int, int) {
+	if m, ok := mappings.Get(id); ok {
+		return m.Width, m.Height
+	}
+	return canvas.Size()
+}
+
+func mappingMessage(m mapping.Mapping) *pb.ReceiverMapping {
+	msg := &pb.ReceiverMapping{
+		ReceiverId: m.ReceiverID,
+		Width:      uint32(m.Width),
+		Height:     uint32(m.Height),
+		X:          int32(m.Offset.X),
+		Y:          int32(m.Offset.Y),
+		Scale:      float32(m.Scale),
+		Peer:       m.Peer,
+	}
+	if !m.Clip.Empty() {
+		msg.Clip = &pb.Rectangle{X: uint32(m.Clip.Min.X), Y: uint32(m.Clip.Min.Y), Width: uint32(m.Clip.Dx()), Height: uint32(m.Clip.Dy())}
+	}
+	return msg
+}
+
+func (s *adminServer) SetReceiverMapping(ctx context.Context, req *pb.ReceiverMapping) (*empty.Empty, error) {
+	m := mapping.Mapping{
+		ReceiverID: req.GetReceiverId(),
+		Width:      int(req.GetWidth()),
+		Height:     int(req.GetHeight()),
+		Offset:     image.Pt(int(req.GetX()), int(req.GetY())),
+		Scale:      float64(req.GetScale()),
+		Peer:       req.GetPeer(),
+	}
+	if clip := req.GetClip(); clip != nil {
+		m.Clip = rectangle(clip)
+		if m.Clip.Empty() {
+			return nil, status.Error(codes.InvalidArgument, "Clip is empty")
+		}
+	}
+	if m.Width > maxCanvasSize || m.Height > maxCanvasSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Receiver canvas size must be at most %dx%d", maxCanvasSize, maxCanvasSize)
+	}
+	err := mappings.Set(m)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Printf("Mapped receiver %s: %+v", m.ReceiverID, m)
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) RemoveReceiverMapping(ctx context.Context, req *pb.ReceiverId) (*empty.Empty, error) {
+	removed, err := mappings.Remove(req.GetReceiverId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !removed {
+		return nil, status.Errorf(codes.NotFound, "Receiver %q is not mapped", req.GetReceiverId())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) ListReceiverMappings(ctx context.Context, req *empty.Empty) (*pb.ReceiverMappings, error) {
+	resp := &pb.ReceiverMappings{Mappings: make([]*pb.ReceiverMapping, 0)}
+	for _, m := range mappings.All() {
+		resp.Mappings = append(resp.Mappings, mappingMessage(m))
+	}
+	return resp, nil
+}
@@ -69,6 +69,22 @@ func (c *ReceiverPerClientMetric) Set(mac string, ip string, value uint64) {
 	c.values[MacIPPair{mac, ip}] = value
 }
 
+func (c *ReceiverMetric) Delete(mac string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	delete(c.values, mac)
+}
+
+func (c *ReceiverPerClientMetric) Delete(mac string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	for k := range c.values {
+		if k.Mac == mac {
+			delete(c.values, k)
+		}
+	}
+}
+
 func NewReceiverMetric(name string, help string) *ReceiverMetric {
 	return &ReceiverMetric{
 		counterDesc: prometheus.NewDesc(name, help, []string{"mac"}, nil),
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/sixelping/sixelping-renderer/pkg/moderation"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Drop the pixels of a delta that fall within a mask or come from a blocked
+//source, pixels without a source of their own are attributed to the sender
+func moderate(req *pb.NewDeltaImageRequest, sender string, width int, height int) (*pb.NewDeltaImageRequest, error) {
+	snapshot := moderator.Snapshot()
+	if snapshot.Empty() {
+		return req, nil
+	}
+	masked, blocked := 0, 0
+	senderBlocked := snapshot.Blocked(sender)
+	filtered, _, err := utils.FilterDelta(req, width, height, func(x, y int, source string) bool {
+		if senderBlocked || snapshot.Blocked(source) {
+			blocked++
+			return false
+		}
+		if snapshot.Masked(x, y) {
+			masked++
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if masked > 0 {
+		promPixelsDropped.WithLabelValues("mask").Add(float64(masked))
+	}
+	if blocked > 0 {
+		promPixelsDropped.WithLabelValues("blocklist").Add(float64(blocked))
+	}
+	return filtered, nil
+}
+
+func maskMessage(m moderation.Mask) *pb.Mask {
+	return &pb.Mask{
+		Id:     m.ID,
+		Rect:   &pb.Rectangle{X: uint32(m.Rect.Min.X), Y: uint32(m.Rect.Min.Y), Width: uint32(m.Rect.Dx()), Height: uint32(m.Rect.Dy())},
+		Bitmap: m.Bitmap,
+	}
+}
+
+func (s *adminServer) AddMask(ctx context.Context, req *pb.Mask) (*pb.Mask, error) {
+	rect := req.GetRect()
+	if rect == nil {
+		return nil, status.Error(codes.InvalidArgument, "Mask has no rectangle")
+	}
+	mask := moderation.Mask{
+		Rect: rectangle(rect),
+	}
+	if len(req.GetBitmap()) > 0 {
+		mask.Bitmap = req.GetBitmap()
+	}
+
+	mask, err := moderator.AddMask(mask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return maskMessage(mask), nil
+}
+
+func (s *adminServer) RemoveMask(ctx context.Context, req *pb.MaskId) (*empty.Empty, error) {
+	found, err := moderator.RemoveMask(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "No mask with id %d", req.GetId())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) BlockSource(ctx context.Context, req *pb.SourcePrefix) (*empty.Empty, error) {
+	err := moderator.Block(req.GetPrefix())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) UnblockSource(ctx context.Context, req *pb.SourcePrefix) (*empty.Empty, error) {
+	found, err := moderator.Unblock(req.GetPrefix())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "%s is not blocked", req.GetPrefix())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) GetModeration(ctx context.Context, req *empty.Empty) (*pb.ModerationState, error) {
+	masks := moderator.Masks()
+	response := &pb.ModerationState{
+		Masks:   make([]*pb.Mask, len(masks)),
+		Blocked: moderator.BlockedPrefixes(),
+	}
+	for i, m := range masks {
+		response.Masks[i] = maskMessage(m)
+	}
+	return response, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sixelping/sixelping-renderer/pkg/quota"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+var limiter *quota.Limiter
+var promQuotaTracked = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "renderer_quota_tracked_prefixes",
+	Help: "Number of source prefixes with used pixel quota",
+}, []string{"bits"})
+
+func setupQuota() {
+	limits := make([]quota.Limit, 0)
+	for _, q := range []struct {
+		bits int
+		spec string
+	}{{128, *quota128Flag}, {64, *quota64Flag}, {48, *quota48Flag}} {
+		if q.spec == "" {
+			continue
+		}
+		limit, err := quota.ParseLimit(q.bits, q.spec)
+		if err != nil {
+			log.Fatalf("Invalid /%d quota: %v", q.bits, err)
+		}
+		limits = append(limits, limit)
+	}
+	if len(limits) == 0 {
+		return
+	}
+
+	limiter = quota.NewLimiter(limits)
+	for _, limit := range limits {
+		log.Printf("Limiting sources to %v", limit)
+	}
+	go quotaExpirer()
+}
+
+//Drop the pixels of sources that exceeded their quota, pixels without a
+//source are not limited. Quota is charged once per source and delta, after
+//the delta was validated, so rejected deltas use no quota.
+func checkQuota(req *pb.NewDeltaImageRequest, width int, height int) (*pb.NewDeltaImageRequest, error) {
+	if limiter == nil {
+		return req, nil
+	}
+	limits := limiter.Limits()
+	prefixes := make(map[string][]string)
+	counts := make(map[string]int)
+	_, _, err := utils.FilterDelta(req, width, height, func(x, y int, source string) bool {
+		counts[source]++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	allowed := make(map[string]int, len(counts))
+	dropped := make([]int, len(limits))
+	limited := false
+	for source, n := range counts {
+		p := make([]string, len(limits))
+		for i, limit := range limits {
+			p[i] = utils.SourcePrefix(source, limit.Bits)
+			if p[i] == "" {
+				p = nil
+				break
+			}
+		}
+		prefixes[source] = p
+		if p == nil {
+			continue
+		}
+		a, exhausted := limiter.TakeN(now, p, n)
+		if exhausted >= 0 {
+			dropped[exhausted] += n - a
+			limited = true
+		}
+		allowed[source] = a
+	}
+	if !limited {
+		return req, nil
+	}
+
+	filtered, _, err := utils.FilterDelta(req, width, height, func(x, y int, source string) bool {
+		if prefixes[source] == nil {
+			return true
+		}
+		allowed[source]--
+		return allowed[source] >= 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, n := range dropped {
+		if n > 0 {
+			promPixelsDropped.WithLabelValues(fmt.Sprintf("quota_%d", limits[i].Bits)).Add(float64(n))
+		}
+	}
+	return filtered, nil
+}
+
+//Periodically forget sources whose quota refilled
+func quotaExpirer() {
+	for {
+		time.Sleep(10 * time.Second)
+		limiter.Expire(time.Now())
+		for i, n := range limiter.Tracked() {
+			promQuotaTracked.WithLabelValues(strconv.Itoa(limiter.Limits()[i].Bits)).Set(float64(n))
+		}
+	}
+}
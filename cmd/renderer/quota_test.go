@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sixelping/sixelping-renderer/pkg/quota"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+func TestCheckQuota(t *testing.T) {
+	limiter = quota.NewLimiter([]quota.Limit{{Bits: 128, Pixels: 5, Window: time.Hour}})
+	defer func() { limiter = nil }()
+
+	req := &pb.NewDeltaImageRequest{
+		Sources: []string{"[2001:db8::1]:1", "[2001:db8::2]:1"},
+		Runs: []*pb.DeltaRun{
+			{X: 0, Y: 0, Length: 8, Source: 1},
+			{X: 0, Y: 1, Length: 3, Source: 2},
+			{X: 0, Y: 2, Length: 8},
+		},
+	}
+	filtered, err := checkQuota(req, 8, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lengths := make([]uint32, 0)
+	for _, r := range filtered.GetRuns() {
+		lengths = append(lengths, r.GetLength())
+	}
+	if len(lengths) != 3 || lengths[0] != 5 || lengths[1] != 3 || lengths[2] != 8 {
+		t.Errorf("Runs of the limited delta have lengths %v, want [5 3 8]", lengths)
+	}
+
+	// Invalid deltas use no quota
+	_, err = checkQuota(&pb.NewDeltaImageRequest{
+		Sources: []string{"[2001:db8::2]:1"},
+		Pixels:  []*pb.DeltaPixel{{X: 0, Y: 0, Source: 1}, {X: 8, Y: 0, Source: 1}},
+	}, 8, 3)
+	if err == nil {
+		t.Fatal("Accepted a pixel outside of the canvas")
+	}
+	filtered, err = checkQuota(&pb.NewDeltaImageRequest{
+		Sources: []string{"[2001:db8::2]:1"},
+		Runs:    []*pb.DeltaRun{{X: 0, Y: 0, Length: 3, Source: 1}},
+	}, 8, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered.GetRuns()) != 1 || filtered.GetRuns()[0].GetLength() != 2 {
+		t.Errorf("Second source was allowed %v after using 3 of 5 pixels", filtered.GetRuns())
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/prometheus/client_golang/prometheus"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type receiver struct {
+	info       *pb.ReceiverInfo
+	address    string
+	registered time.Time
+	lastSeen   time.Time
+	deltas     uint64
+	rateDeltas uint64
+	rate       float64
+}
+
+//Tracks registered receivers, exporting their state as metrics
+type receiverRegistry struct {
+	receivers    map[string]*receiver
+	timeout      time.Duration
+	lastRate     time.Time
+	lastSeenDesc *prometheus.Desc
+	deltasDesc   *prometheus.Desc
+	rateDesc     *prometheus.Desc
+	upDesc       *prometheus.Desc
+	mut          sync.Mutex
+}
+
+var receivers *receiverRegistry
+
+//Receivers stale for this many receiver timeouts are forgotten, receiver ids
+//are chosen by clients so the registry would grow without bound otherwise
+const receiverEvictTimeouts = 10
+
+func newReceiverRegistry(timeout time.Duration) *receiverRegistry {
+	labels := []string{"receiver", "mac"}
+	return &receiverRegistry{
+		receivers:    make(map[string]*receiver),
+		timeout:      timeout,
+		lastRate:     time.Now(),
+		lastSeenDesc: prometheus.NewDesc("renderer_receiver_last_seen_seconds", "Unix time a registered receiver was last seen", labels, nil),
+		deltasDesc:   prometheus.NewDesc("renderer_receiver_deltas_total", "Number of deltas applied from a registered receiver", labels, nil),
+		rateDesc:     prometheus.NewDesc("renderer_receiver_delta_rate", "Deltas per second from a registered receiver", labels, nil),
+		upDesc:       prometheus.NewDesc("renderer_receiver_up", "Whether a registered receiver was seen within the receiver timeout", labels, nil),
+	}
+}
+
+func (r *receiverRegistry) register(info *pb.ReceiverInfo, address string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	now := time.Now()
+	rec, ok := r.receivers[info.GetId()]
+	if !ok {
+		rec = &receiver{registered: now}
+		r.receivers[info.GetId()] = rec
+	}
+	rec.info = info
+	rec.address = address
+	rec.lastSeen = now
+}
+
+//Mark a receiver as seen, reports whether it is registered
+func (r *receiverRegistry) seen(id string) bool {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	rec, ok := r.receivers[id]
+	if ok {
+		rec.lastSeen = time.Now()
+	}
+	return ok
+}
+
+//Mark the receivers with a MAC address as seen
+func (r *receiverRegistry) seenMac(mac string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, rec := range r.receivers {
+		if rec.info.GetMac() == mac {
+			rec.lastSeen = time.Now()
+		}
+	}
+}
+
+func (r *receiverRegistry) delta(id string) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if rec, ok := r.receivers[id]; ok {
+		rec.lastSeen = time.Now()
+		rec.deltas++
+	}
+}
+
+func (r *receiverRegistry) stale(rec *receiver, now time.Time) bool {
+	return now.Sub(rec.lastSeen) > r.timeout
+}
+
+//Periodically update the delta rates and evict long stale receivers
+func (r *receiverRegistry) run(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		r.mut.Lock()
+		now := time.Now()
+		elapsed := now.Sub(r.lastRate).Seconds()
+		for _, rec := range r.receivers {
+			rec.rate = float64(rec.deltas-rec.rateDeltas) / elapsed
+			rec.rateDeltas = rec.deltas
+		}
+		r.lastRate = now
+		r.mut.Unlock()
+
+		for _, mac := range r.evict(now) {
+			forgetMac(mac)
+		}
+	}
+}
+
+//Forget the receivers stale for receiverEvictTimeouts, which also removes
+//their metrics. Returns the MAC addresses no remaining receiver has.
+func (r *receiverRegistry) evict(now time.Time) []string {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	macs := make(map[string]bool)
+	for id, rec := range r.receivers {
+		if now.Sub(rec.lastSeen) > receiverEvictTimeouts*r.timeout {
+			delete(r.receivers, id)
+			if rec.info.GetMac() != "" {
+				macs[rec.info.GetMac()] = true
+			}
+		}
+	}
+	for _, rec := range r.receivers {
+		delete(macs, rec.info.GetMac())
+	}
+	evicted := make([]string, 0, len(macs))
+	for mac := range macs {
+		evicted = append(evicted, mac)
+	}
+	sort.Strings(evicted)
+	return evicted
+}
+
+func (r *receiverRegistry) list() []*pb.ReceiverStatus {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	now := time.Now()
+	list := make([]*pb.ReceiverStatus, 0, len(r.receivers))
+	for _, rec := range r.receivers {
+		list = append(list, &pb.ReceiverStatus{
+			Info:       rec.info,
+			Address:    rec.address,
+			Registered: rec.registered.UnixNano(),
+			LastSeen:   rec.lastSeen.UnixNano(),
+			Deltas:     rec.deltas,
+			DeltaRate:  rec.rate,
+			Stale:      r.stale(rec, now),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].GetInfo().GetId() < list[j].GetInfo().GetId()
+	})
+	return list
+}
+
+func (r *receiverRegistry) Describe(ch chan<- *prometheus.Desc) {
+	ch <- r.lastSeenDesc
+	ch <- r.deltasDesc
+	ch <- r.rateDesc
+	ch <- r.upDesc
+}
+
+func (r *receiverRegistry) Collect(ch chan<- prometheus.Metric) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	now := time.Now()
+	for id, rec := range r.receivers {
+		mac := rec.info.GetMac()
+		up := 1.0
+		if r.stale(rec, now) {
+			up = 0.0
+		}
+		ch <- prometheus.MustNewConstMetric(r.lastSeenDesc, prometheus.GaugeValue, float64(rec.lastSeen.UnixNano())/1e9, id, mac)
+		ch <- prometheus.MustNewConstMetric(r.deltasDesc, prometheus.CounterValue, float64(rec.deltas), id, mac)
+		ch <- prometheus.MustNewConstMetric(r.rateDesc, prometheus.GaugeValue, rec.rate, id, mac)
+		ch <- prometheus.MustNewConstMetric(r.upDesc, prometheus.GaugeValue, up, id, mac)
+	}
+}
+
+func (s *server) RegisterReceiver(ctx context.Context, req *pb.ReceiverInfo) (*pb.RegisterReceiverResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Receiver id is required")
+	}
+	receivers.register(proto.Clone(req).(*pb.ReceiverInfo), deltaSource(ctx))
+	width, height := receiverSize(req.GetId())
+	return &pb.RegisterReceiverResponse{
+		HeartbeatInterval: int64(*receiverTimeoutFlag / 3),
+		Width:             uint32(width),
+		Height:            uint32(height),
+	}, nil
+}
+
+func (s *server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*empty.Empty, error) {
+	if !receivers.seen(req.GetReceiverId()) {
+		return nil, status.Errorf(codes.NotFound, "Receiver %q is not registered", req.GetReceiverId())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *server) ListReceivers(ctx context.Context, req *empty.Empty) (*pb.ReceiverList, error) {
+	return &pb.ReceiverList{Receivers: receivers.list()}, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+//Count the metric series a collector exports
+func countMetrics(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
+
+func TestReceiverEviction(t *testing.T) {
+	r := newReceiverRegistry(time.Second)
+	r.register(&pb.ReceiverInfo{Id: "a", Mac: "00:00:00:00:00:01"}, "192.0.2.1:1")
+	r.register(&pb.ReceiverInfo{Id: "b", Mac: "00:00:00:00:00:02"}, "192.0.2.2:1")
+	r.register(&pb.ReceiverInfo{Id: "c", Mac: "00:00:00:00:00:02"}, "192.0.2.3:1")
+	r.register(&pb.ReceiverInfo{Id: "d", Mac: "00:00:00:00:00:03"}, "192.0.2.4:1")
+	if n := countMetrics(r); n != 16 {
+		t.Fatalf("Registry exports %d series, want 16", n)
+	}
+
+	now := time.Now().Add(receiverEvictTimeouts * time.Second / 2)
+	if evicted := r.evict(now); len(evicted) != 0 || len(r.list()) != 4 {
+		t.Errorf("Evicted %v of receivers stale for less than the eviction timeout", evicted)
+	}
+
+	now = time.Now().Add(receiverEvictTimeouts*time.Second + time.Second)
+	r.mut.Lock()
+	r.receivers["d"].lastSeen = now
+	r.receivers["c"].lastSeen = now
+	r.mut.Unlock()
+	evicted := r.evict(now)
+	// The MAC of b is still used by c
+	if !reflect.DeepEqual(evicted, []string{"00:00:00:00:00:01"}) {
+		t.Errorf("Evicted MACs %v", evicted)
+	}
+	list := r.list()
+	if len(list) != 2 || list[0].GetInfo().GetId() != "c" || list[1].GetInfo().GetId() != "d" {
+		t.Errorf("Receivers after eviction %v", list)
+	}
+	if n := countMetrics(r); n != 8 {
+		t.Errorf("Registry exports %d series after eviction, want 8", n)
+	}
+}
+
+func TestReceiverMetricDelete(t *testing.T) {
+	m := NewReceiverPerClientMetric("test_pings", "Test")
+	m.Set("a", "192.0.2.1", 1)
+	m.Set("a", "192.0.2.2", 1)
+	m.Set("b", "192.0.2.1", 1)
+	m.Delete("a")
+	if n := countMetrics(m); n != 1 {
+		t.Errorf("Metric exports %d series after deleting a MAC, want 1", n)
+	}
+}
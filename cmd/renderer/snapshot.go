@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+//Serializes saving, the snapshotter and shutdown share the temporary file
+var snapshotMut sync.Mutex
+
+//Write the canvas to the snapshot file, replacing it atomically
+func saveSnapshot() error {
+	snapshotMut.Lock()
+	defer snapshotMut.Unlock()
+	tmpPath := *snapshotFlag + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	err = canvas.WriteSnapshot(f)
+	if err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, *snapshotFlag)
+}
+
+func restoreSnapshot() error {
+	f, err := os.Open(*snapshotFlag)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return canvas.ReadSnapshot(f)
+}
+
+func snapshotter() {
+	for {
+		time.Sleep(*snapshotIntervalFlag)
+		err := saveSnapshot()
+		if err != nil {
+			log.Printf("Failed to save snapshot: %v", err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	"github.com/sixelping/sixelping-renderer/pkg/deltalog"
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+	historypkg "github.com/sixelping/sixelping-renderer/pkg/history"
+	"github.com/sixelping/sixelping-renderer/pkg/mjpeg"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+var logFlag = flag.String("log", "", "Delta log directory to replay")
+var widthFlag = flag.Int("width", 1920, "Canvas Width, for delta logs that do not record the canvas size")
+var heightFlag = flag.Int("height", 1080, "Canvas Height, for delta logs that do not record the canvas size")
+var pixTimeoutFlag = flag.Float64("pixeltime", 1.0, "Canvas pixel timeout in seconds")
+var fadeFlag = flag.String("fade", "linear", "Pixel fade curve (linear, exponential, step, easeout, persistent, desaturate)")
+var fpsFlag = flag.Int("fps", 25, "Output frames per second")
+var speedFlag = flag.Float64("speed", 1.0, "Simulated seconds per output second")
+var outputFlag = flag.String("output", "png", "Output to produce (png, mjpeg, raw)")
+var dirFlag = flag.String("dir", ".", "Directory to write the png sequence to")
+var listenFlag = flag.String("listen", ":8082", "Listen address of the mjpeg stream")
+var rawFormatFlag = flag.String("rawformat", "rgb24", "Raw frame pixel format (rgb24, rgba, bgra, rgb565, yuv420p)")
+var rawHeaderFlag = flag.Bool("rawheader", false, "Prefix every raw frame with a header")
+var historyFlag = flag.Duration("history", time.Hour, "How long deltas are retained to replay rollbacks, at least the -history of the recording renderer, 0 to skip rollbacks")
+
+//Simulated time, deltas are stamped with the time they were received at
+var simTime time.Time
+
+func nextRecord(reader *deltalog.Reader) (*pb.DeltaRecord, error) {
+	rec, err := reader.Next()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read delta log: %v", err)
+	}
+	return rec, nil
+}
+
+//Apply a record to the canvas, rollbacks and source drops are applied through
+//the history which is nil when they are skipped
+func applyRecord(rec *pb.DeltaRecord, canvas *canvaspkg.Canvas, history *historypkg.History) error {
+	if rec.GetClear() {
+		if history != nil {
+			history.Clear(canvas, simTime)
+		} else {
+			canvas.Clear()
+		}
+		return nil
+	}
+	if resize := rec.GetResize(); resize != nil {
+		// Segments start with the canvas size, which usually matches already
+		width, height := canvas.Size()
+		if int(resize.GetWidth()) == width && int(resize.GetHeight()) == height {
+			return nil
+		}
+		policy := canvaspkg.ResizePolicy(resize.GetPolicy())
+		if history != nil {
+			return history.Resize(canvas, int(resize.GetWidth()), int(resize.GetHeight()), policy, simTime)
+		}
+		return canvas.Resize(int(resize.GetWidth()), int(resize.GetHeight()), policy)
+	}
+	if rollback := rec.GetRollback(); rollback != nil {
+		if history == nil {
+			return errors.New("Rollbacks are skipped")
+		}
+		_, err := history.Rollback(canvas, rollback.GetTimestamp(), simTime)
+		return err
+	}
+	if drop := rec.GetDropSource(); drop != nil {
+		if history == nil {
+			return errors.New("Source drops are skipped")
+		}
+		_, err := history.DropSource(canvas, drop.GetSource(), drop.GetFrom(), drop.GetTo(), simTime)
+		return err
+	}
+	if history != nil {
+		return history.Apply(canvas, simTime, rec.GetSource(), rec.GetDelta())
+	}
+	return utils.ApplyDelta(canvas, rec.GetDelta())
+}
+
+func replay(reader *deltalog.Reader, canvas *canvaspkg.Canvas, out sink) error {
+	rec, err := nextRecord(reader)
+	if err != nil {
+		return err
+	}
+	if rec == nil {
+		return fmt.Errorf("Delta log %s is empty", *logFlag)
+	}
+
+	var history *historypkg.History
+	if *historyFlag > 0 {
+		history = historypkg.New(canvas, time.Unix(0, rec.GetTimestamp()), *historyFlag, 0, 0)
+	}
+
+	step := time.Duration(float64(time.Second) * (*speedFlag) / float64(*fpsFlag))
+	pixelTimeout := time.Duration((*pixTimeoutFlag) * float64(time.Second))
+	frameTime := time.Unix(0, rec.GetTimestamp())
+	var end time.Time
+	number := uint64(0)
+
+	for rec != nil || frameTime.Before(end) {
+		frameTime = frameTime.Add(step)
+
+		for rec != nil && rec.GetTimestamp() <= frameTime.UnixNano() {
+			simTime = time.Unix(0, rec.GetTimestamp())
+			err := applyRecord(rec, canvas, history)
+			if err != nil {
+				log.Printf("Skipping record from %s at %v: %v", rec.GetSource(), simTime, err)
+			}
+
+			rec, err = nextRecord(reader)
+			if err != nil {
+				return err
+			}
+			if rec == nil {
+				// Keep going until the last pixels have faded
+				end = simTime.Add(pixelTimeout)
+			}
+		}
+
+		img, err := canvas.GetImage(frameTime)
+		if err != nil {
+			return fmt.Errorf("Failed to render frame: %v", err)
+		}
+
+		number++
+		err = out.WriteFrame(frame.NewFrame(number, frameTime, img))
+		if err != nil {
+			return fmt.Errorf("Failed to write frame: %v", err)
+		}
+	}
+
+	log.Printf("Replayed %d frames up to %v", number, frameTime)
+	return nil
+}
+
+func newSink() sink {
+	switch *outputFlag {
+	case "png":
+		return &pngSink{dir: *dirFlag}
+	case "mjpeg":
+		streamer := mjpeg.NewStreamer()
+		http.Handle("/stream.mjpeg", streamer)
+		go func() {
+			log.Fatal(http.ListenAndServe(*listenFlag, nil))
+		}()
+		log.Printf("Streaming on %s/stream.mjpeg", *listenFlag)
+		return &mjpegSink{streamer: streamer, interval: time.Second / time.Duration(int64(*fpsFlag))}
+	case "raw":
+		s, err := newRawSink(*rawFormatFlag, *rawHeaderFlag)
+		if err != nil {
+			log.Fatalf("Invalid raw output: %v", err)
+		}
+		return s
+	}
+	log.Fatalf("Unknown output %q", *outputFlag)
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	if *logFlag == "" {
+		log.Fatal("No delta log given, use -log")
+	}
+	if *fpsFlag <= 0 || *speedFlag <= 0 {
+		log.Fatal("Both -fps and -speed must be positive")
+	}
+
+	fade, err := canvaspkg.ParseFadeFunc(*fadeFlag)
+	if err != nil {
+		log.Fatalf("Invalid fade: %v", err)
+	}
+	canvas := canvaspkg.NewCanvas(*widthFlag, *heightFlag, uint64((*pixTimeoutFlag)*1000000000))
+	canvas.SetFadeFunc(fade)
+	canvas.SetClock(func() time.Time { return simTime })
+
+	reader, err := deltalog.NewReader(*logFlag)
+	if err != nil {
+		log.Fatalf("Failed to open delta log: %v", err)
+	}
+
+	out := newSink()
+	err = replay(reader, canvas, out)
+	reader.Close()
+	// Close the sink before exiting on errors, so buffered output is written
+	if cerr := out.Close(); cerr != nil && err == nil {
+		err = fmt.Errorf("Failed to close output: %v", cerr)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+	"github.com/sixelping/sixelping-renderer/pkg/mjpeg"
+	"github.com/sixelping/sixelping-renderer/pkg/rawstream"
+)
+
+//A sink receives the replayed frames in order
+type sink interface {
+	WriteFrame(f *frame.Frame) error
+	Close() error
+}
+
+//Writes numbered png files, as fast as they can be rendered
+type pngSink struct {
+	dir string
+}
+
+func (s *pngSink) WriteFrame(f *frame.Frame) error {
+	return ioutil.WriteFile(filepath.Join(s.dir, fmt.Sprintf("frame-%08d.png", f.Number)), f.Bytes(frame.PNG), 0644)
+}
+
+func (s *pngSink) Close() error {
+	return nil
+}
+
+//Streams frames over http in real time at the output frame rate
+type mjpegSink struct {
+	streamer *mjpeg.Streamer
+	interval time.Duration
+	nextTime time.Time
+}
+
+func (s *mjpegSink) WriteFrame(f *frame.Frame) error {
+	if s.nextTime.IsZero() {
+		s.nextTime = time.Now()
+	}
+	bts := f.Bytes(frame.JPEG)
+	err := s.streamer.NewFrame(&bts)
+	if err != nil {
+		return err
+	}
+
+	s.nextTime = s.nextTime.Add(s.interval)
+	time.Sleep(time.Until(s.nextTime))
+	return nil
+}
+
+func (s *mjpegSink) Close() error {
+	s.streamer.Close()
+	return nil
+}
+
+//Writes raw frames to stdout, e.g. to pipe them into ffmpeg
+type rawSink struct {
+	encoder *rawstream.Encoder
+	out     *bufio.Writer
+}
+
+func newRawSink(formatName string, headers bool) (*rawSink, error) {
+	format, err := rawstream.ParseFormat(formatName)
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := rawstream.NewEncoder(format, rawstream.None, headers)
+	if err != nil {
+		return nil, err
+	}
+	return &rawSink{encoder: encoder, out: bufio.NewWriter(os.Stdout)}, nil
+}
+
+func (s *rawSink) WriteFrame(f *frame.Frame) error {
+	packet, err := s.encoder.Packet(f)
+	if err != nil {
+		return err
+	}
+	_, err = s.out.Write(packet)
+	return err
+}
+
+func (s *rawSink) Close() error {
+	return s.out.Flush()
+}
@@ -8,7 +8,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"time"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
@@ -18,46 +18,88 @@ import (
 
 var listenFlag = flag.String("listen", ":8080", "Listen address")
 var rendererFlag = flag.String("renderer", "localhost:50051", "Renderer address")
-var canvas image.Image
+var canvas image.Image = utils.BlackImage(0, 0)
 var fps int
+var canvasMut sync.RWMutex
 
-func imageHandler(w http.ResponseWriter, r *http.Request) {
+//Latest image and the renderer fps
+func getCanvas() (image.Image, int) {
+	canvasMut.RLock()
+	defer canvasMut.RUnlock()
+	return canvas, fps
+}
+
+func setCanvas(img image.Image) {
+	canvasMut.Lock()
+	defer canvasMut.Unlock()
+	canvas = img
+}
 
-	imgBytes := utils.ImageToBytes(canvas)
+func imageHandler(w http.ResponseWriter, r *http.Request) {
+	img, _ := getCanvas()
+	imgBytes := utils.ImageToBytes(img)
 	w.Header().Set("Content-Type", "image/png")
 	w.Header().Set("Content-Length", strconv.Itoa(len(imgBytes)))
 	w.Header().Set("Cache-Control", "no-cache, no-store, no-transform")
 	w.Write(imgBytes)
 }
 
+//Follow the renderer parameters, restarting the image stream when the fps
+//changes
 func poller(client pb.SixelpingRendererClient) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-
-	parameters, err := client.GetCanvasParameters(ctx, &empty.Empty{})
+	watch, err := client.WatchCanvasParameters(context.Background(), &empty.Empty{})
 	if err != nil {
 		log.Fatalf("Failed to poll renderer: %v", err)
 	}
 
-	canvas = utils.BlackImage(int(parameters.GetWidth()), int(parameters.GetHeight()))
-	fps = int(parameters.GetFps())
+	cancel := func() {}
+	for {
+		parameters, err := watch.Recv()
+		if err != nil {
+			log.Fatalf("Failed to poll renderer: %v", err)
+		}
+
+		canvasMut.Lock()
+		size := canvas.Bounds().Size()
+		if size.X != int(parameters.GetWidth()) || size.Y != int(parameters.GetHeight()) {
+			canvas = utils.BlackImage(int(parameters.GetWidth()), int(parameters.GetHeight()))
+		}
+		fpsChanged := fps != int(parameters.GetFps())
+		fps = int(parameters.GetFps())
+		canvasMut.Unlock()
+
+		if fpsChanged {
+			cancel()
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go streamImages(ctx, client, parameters.GetFps())
+		}
+	}
+}
+
+func streamImages(ctx context.Context, client pb.SixelpingRendererClient, maxFps uint32) {
+	stream, err := client.StreamRenderedImages(ctx, &pb.StreamRenderedImagesRequest{
+		Encoding: pb.ImageEncoding_JPEG,
+		MaxFps:   maxFps,
+	})
+	if err != nil {
+		log.Fatalf("Failed to poll renderer: %v", err)
+	}
 
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
-		response, err := client.GetRenderedImage(ctx, &empty.Empty{})
-		if err == nil {
-			img, _, err := image.Decode(bytes.NewReader(response.GetImage()))
-			if err == nil {
-				canvas = img
-			} else {
-				log.Fatalf("Failed to poll renderer: %v", err)
+		response, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			log.Fatalf("Failed to poll renderer: %v", err)
+		}
+		img, _, err := image.Decode(bytes.NewReader(response.GetImage()))
+		if err == nil {
+			setCanvas(img)
 		} else {
 			log.Fatalf("Failed to poll renderer: %v", err)
 		}
-
-		time.Sleep(time.Second / time.Duration(int64(parameters.GetFps())))
 	}
 }
 
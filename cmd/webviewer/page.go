@@ -61,8 +61,9 @@ function i2() {
 			Height int
 			Fps    int
 		}
-		dets.Width = canvas.Bounds().Size().X
-		dets.Height = canvas.Bounds().Size().Y
+		img, fps := getCanvas()
+		dets.Width = img.Bounds().Size().X
+		dets.Height = img.Bounds().Size().Y
 		dets.Fps = fps
 		pageTemplate.Execute(w, dets)
 	} else {
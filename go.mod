@@ -6,6 +6,8 @@ require (
 	github.com/golang/protobuf v1.3.3
 	github.com/gorilla/handlers v1.4.2
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
+	github.com/klauspost/compress v1.10.0
+	github.com/pierrec/lz4 v2.4.1+incompatible
 	github.com/prometheus/client_golang v1.4.0
 	github.com/prometheus/common v0.9.1
 	golang.org/x/image v0.0.0-20200119044424-58c23975cae1
@@ -2,20 +2,47 @@ package canvas
 
 import (
 	"errors"
+	"fmt"
 	"image"
 	"image/draw"
+	"sync"
 	"time"
 )
 
+// Pixel is a single changed pixel of a sparse delta. Source optionally
+// identifies who set it.
+type Pixel struct {
+	X, Y    int
+	R, G, B uint8
+	Source  string
+}
+
+// Run is a horizontal run of Length equally colored pixels starting at X, Y.
+type Run struct {
+	X, Y, Length int
+	R, G, B      uint8
+	Source       string
+}
+
+// Canvas holds the pixel planes. All methods are safe for concurrent use; the
+// exported planes and dimensions must not be accessed directly while the
+// canvas is shared, as Resize replaces them.
 type Canvas struct {
 	R                []uint8
 	G                []uint8
 	B                []uint8
 	LastUpdated      []uint64
+	Owner            []uint32
 	Width            int
 	Height           int
 	overlay          image.Image
 	PixelTimeoutNano uint64
+	fade             FadeFunc
+	clock            func() time.Time
+	sources          []string
+	sourceIndex      map[string]uint32
+	compactAt        int
+	mut              sync.RWMutex
 }
 
 func NewCanvas(width int, height int, pixelTimeoutNano uint64) *Canvas {
@@ -26,11 +53,96 @@ func NewCanvas(width int, height int, pixelTimeoutNano uint64) *Canvas {
 		G:                make([]uint8, width*height),
 		B:                make([]uint8, width*height),
 		LastUpdated:      make([]uint64, width*height),
+		Owner:            make([]uint32, width*height),
 		PixelTimeoutNano: pixelTimeoutNano,
+		fade:             FadeLinear,
+		clock:            time.Now,
+		sources:          []string{""},
+		sourceIndex:      make(map[string]uint32),
+		compactAt:        maxSources,
+	}
+}
+
+// Clone returns an independent copy of the pixel state, timeout and fade.
+func (c *Canvas) Clone() *Canvas {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+	clone := NewCanvas(c.Width, c.Height, c.PixelTimeoutNano)
+	clone.fade = c.fade
+	copy(clone.R, c.R)
+	copy(clone.G, c.G)
+	copy(clone.B, c.B)
+	copy(clone.LastUpdated, c.LastUpdated)
+	clone.copySources(c)
+	return clone
+}
+
+// CopyPixels replaces the pixel state with that of another canvas of the same
+// size.
+func (c *Canvas) CopyPixels(from *Canvas) error {
+	from.mut.RLock()
+	defer from.mut.RUnlock()
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if from.Width != c.Width || from.Height != c.Height {
+		return errors.New("Invalid width/height")
+	}
+	copy(c.R, from.R)
+	copy(c.G, from.G)
+	copy(c.B, from.B)
+	copy(c.LastUpdated, from.LastUpdated)
+	c.copySources(from)
+	return nil
+}
+
+// copySources takes over the owner plane and source table of another canvas.
+func (c *Canvas) copySources(from *Canvas) {
+	copy(c.Owner, from.Owner)
+	c.sources = append([]string{}, from.sources...)
+	c.sourceIndex = make(map[string]uint32, len(from.sourceIndex))
+	for k, v := range from.sourceIndex {
+		c.sourceIndex[k] = v
+	}
+	c.compactAt = from.compactAt
+}
+
+// SetClock replaces the time source deltas are timestamped with, which allows
+// driving the canvas with simulated time.
+func (c *Canvas) SetClock(clock func() time.Time) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.clock = clock
+}
+
+// SetFadeFunc changes how pixels fade out over the pixel timeout.
+func (c *Canvas) SetFadeFunc(fade FadeFunc) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.fade = fade
+}
+
+// SetPixelTimeout changes how long pixels take to fade out.
+func (c *Canvas) SetPixelTimeout(pixelTimeoutNano uint64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.PixelTimeoutNano = pixelTimeoutNano
+}
+
+// Clear resets all pixels to black and unset.
+func (c *Canvas) Clear() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	for i := range c.R {
+		c.R[i], c.G[i], c.B[i], c.LastUpdated[i], c.Owner[i] = 0, 0, 0, 0, 0
 	}
+	c.sources = []string{""}
+	c.sourceIndex = make(map[string]uint32)
+	c.updateCompactAt()
 }
 
 func (c *Canvas) SetOverlayImage(img image.Image) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
 	if img.Bounds().Max.X != c.Width || img.Bounds().Max.Y != c.Height {
 		return errors.New("Invalid width/height")
 	}
@@ -39,55 +151,142 @@ func (c *Canvas) SetOverlayImage(img image.Image) error {
 	return nil
 }
 
-func (c *Canvas) AddDelta(deltaImage []byte) error {
-	now := uint64(time.Now().UnixNano())
+// Delta is a full-canvas delta image and/or a sparse list of changed pixels
+// and runs, applied by Apply as a whole.
+type Delta struct {
+	Image       []byte
+	Format      PixelFormat
+	ImageSource string
+	Pixels      []Pixel
+	Runs        []Run
+}
 
-	for x := 0; x < c.Width; x++ {
-		for y := 0; y < c.Height; y++ {
-			i := (y*(c.Width) + x) * 4
-			r, g, b, a := deltaImage[i+2], deltaImage[i+1], deltaImage[i], deltaImage[i+3]
-			if a > 0 {
-				c.R[y*c.Width+x] = r
-				c.G[y*c.Width+x] = g
-				c.B[y*c.Width+x] = b
-				c.LastUpdated[y*c.Width+x] = now
+// Apply validates the whole delta against the canvas size and then applies
+// it under a single lock, an invalid delta leaves the canvas unchanged.
+func (c *Canvas) Apply(d Delta) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	err := c.validate(d)
+	if err != nil {
+		return err
+	}
+	c.apply(d)
+	return nil
+}
+
+// validate checks a delta against the canvas size, must be called with the
+// lock held.
+func (c *Canvas) validate(d Delta) error {
+	if len(d.Image) > 0 {
+		err := ValidateDelta(d.Image, d.Format, c.Width, c.Height)
+		if err != nil {
+			return err
+		}
+		err = validateSource(d.ImageSource)
+		if err != nil {
+			return err
+		}
+	}
+	for _, p := range d.Pixels {
+		if p.X < 0 || p.Y < 0 || p.X >= c.Width || p.Y >= c.Height {
+			return fmt.Errorf("%w: pixel (%d, %d) outside of %dx%d canvas", ErrInvalidDelta, p.X, p.Y, c.Width, c.Height)
+		}
+		err := validateSource(p.Source)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range d.Runs {
+		if r.X < 0 || r.Y < 0 || r.Length < 0 || r.X+r.Length > c.Width || r.Y >= c.Height {
+			return fmt.Errorf("%w: run (%d, %d)+%d outside of %dx%d canvas", ErrInvalidDelta, r.X, r.Y, r.Length, c.Width, c.Height)
+		}
+		err := validateSource(r.Source)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply writes a validated delta, must be called with the write lock held.
+func (c *Canvas) apply(d Delta) {
+	now := uint64(c.clock().UnixNano())
+
+	if len(d.Image) > 0 {
+		bpp := d.Format.BytesPerPixel()
+		owner := c.internSource(d.ImageSource)
+		for i := 0; i < c.Width*c.Height; i++ {
+			r, g, b, set := d.Format.DecodePixel(d.Image[i*bpp:])
+			if set {
+				c.R[i] = r
+				c.G[i] = g
+				c.B[i] = b
+				c.LastUpdated[i] = now
+				c.Owner[i] = owner
 			}
 		}
 	}
 
+	for _, p := range d.Pixels {
+		i := p.Y*c.Width + p.X
+		c.R[i] = p.R
+		c.G[i] = p.G
+		c.B[i] = p.B
+		c.LastUpdated[i] = now
+		c.Owner[i] = c.internSource(p.Source)
+	}
+
+	for _, r := range d.Runs {
+		start := r.Y*c.Width + r.X
+		owner := c.internSource(r.Source)
+		for i := start; i < start+r.Length; i++ {
+			c.R[i] = r.R
+			c.G[i] = r.G
+			c.B[i] = r.B
+			c.LastUpdated[i] = now
+			c.Owner[i] = owner
+		}
+	}
+}
+
+// AddDelta applies a full-canvas delta image in the given pixel format, all
+// changed pixels are attributed to source.
+func (c *Canvas) AddDelta(deltaImage []byte, format PixelFormat, source string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	err := ValidateDelta(deltaImage, format, c.Width, c.Height)
+	if err != nil {
+		return err
+	}
+	err = validateSource(source)
+	if err != nil {
+		return err
+	}
+	c.apply(Delta{Image: deltaImage, Format: format, ImageSource: source})
 	return nil
 }
 
+// AddPixels applies a sparse delta. Unlike AddDelta it only touches the given
+// pixels, so its cost is proportional to the number of changed pixels.
+func (c *Canvas) AddPixels(pixels []Pixel, runs []Run) error {
+	return c.Apply(Delta{Pixels: pixels, Runs: runs})
+}
+
 func (c *Canvas) drawImage(now uint64, img *image.RGBA) error {
 	for x := 0; x < c.Width; x++ {
 		for y := 0; y < c.Height; y++ {
 			lu := c.LastUpdated[y*c.Width+x]
-			dt := now - lu
-
-			fac := float32(0.0)
-			if c.LastUpdated[y*c.Width+x] > now {
-				//If pixel is newer than now, draw it fully
-				fac = float32(1.0)
-			} else {
-				//Calculate darkness
-				fac = float32(1.0) - (float32(dt) / float32(c.PixelTimeoutNano))
-
-				if fac < 0.0 {
-					fac = float32(0.0)
-				}
-			}
-
 			index := (y-img.Rect.Min.Y)*img.Stride + (x-img.Rect.Min.X)*4
+			r, g, b := c.R[y*c.Width+x], c.G[y*c.Width+x], c.B[y*c.Width+x]
 
-			if fac > 0.0 {
-				img.Pix[index] = uint8(fac * float32(c.R[y*c.Width+x]))
-				img.Pix[index+1] = uint8(fac * float32(c.G[y*c.Width+x]))
-				img.Pix[index+2] = uint8(fac * float32(c.B[y*c.Width+x]))
-			} else {
-				img.Pix[index] = 0
-				img.Pix[index+1] = 0
-				img.Pix[index+2] = 0
+			//If pixel is newer than now, draw it fully
+			if lu <= now {
+				r, g, b = c.fade(r, g, b, now-lu, c.PixelTimeoutNano)
 			}
+
+			img.Pix[index] = r
+			img.Pix[index+1] = g
+			img.Pix[index+2] = b
 			img.Pix[index+3] = 255
 		}
 	}
@@ -95,16 +294,18 @@ func (c *Canvas) drawImage(now uint64, img *image.RGBA) error {
 }
 
 func (c *Canvas) GetImage(now time.Time) (*image.RGBA, error) {
+	c.mut.RLock()
 	img := image.NewRGBA(image.Rectangle{image.Point{0, 0}, image.Point{c.Width, c.Height}})
-
 	err := c.drawImage(uint64(now.UnixNano()), img)
+	overlay := c.overlay
+	c.mut.RUnlock()
 	if err != nil {
 		return nil, err
 	}
 
-	// Add overlay image ontop
-	if c.overlay != nil {
-		draw.Draw(img, img.Bounds(), c.overlay, image.ZP, draw.Over)
+	// Add overlay image ontop, overlays are never modified once set
+	if overlay != nil {
+		draw.Draw(img, img.Bounds(), overlay, image.ZP, draw.Over)
 	}
 
 	return img, nil
@@ -0,0 +1,104 @@
+package canvas
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestApplyInvalidDeltaLeavesCanvasUnchanged(t *testing.T) {
+	c := NewCanvas(2, 2, 1000000000)
+	image := []byte{255, 0, 0, 0, 255, 0, 0, 0, 255, 255, 255, 255}
+	tests := []struct {
+		name  string
+		delta Delta
+	}{
+		{"pixel outside", Delta{Image: image, Format: RGB24, Pixels: []Pixel{{X: 5, Y: 5, R: 255}}}},
+		{"run outside", Delta{Image: image, Format: RGB24, Runs: []Run{{X: 1, Y: 0, Length: 2, G: 255}}}},
+		{"short image", Delta{Image: image[:9], Format: RGB24, Pixels: []Pixel{{X: 0, Y: 0, R: 255}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := c.Apply(test.delta)
+			if !errors.Is(err, ErrInvalidDelta) {
+				t.Fatalf("Apply returned %v, want ErrInvalidDelta", err)
+			}
+			for i := range c.R {
+				if c.R[i] != 0 || c.G[i] != 0 || c.B[i] != 0 || c.LastUpdated[i] != 0 {
+					t.Fatalf("Pixel %d was changed by an invalid delta", i)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyImageAndPixels(t *testing.T) {
+	c := NewCanvas(2, 2, 1000000000)
+	err := c.Apply(Delta{
+		Image:       []byte{255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		Format:      RGB24,
+		ImageSource: "image",
+		Pixels:      []Pixel{{X: 1, Y: 1, B: 255, Source: "pixel"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		x, y    int
+		r, g, b uint8
+		source  string
+	}{{0, 0, 255, 0, 0, "image"}, {1, 0, 0, 0, 0, ""}, {1, 1, 0, 0, 255, "pixel"}} {
+		info, err := c.GetPixelInfo(test.x, test.y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.R != test.r || info.G != test.g || info.B != test.b || info.Source != test.source {
+			t.Errorf("Pixel (%d, %d) is %+v", test.x, test.y, info)
+		}
+	}
+}
+
+// Run with -race to check the locking of the canvas
+func TestConcurrentAccess(t *testing.T) {
+	c := NewCanvas(16, 16, 1000000000)
+	var wg sync.WaitGroup
+	run := func(f func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				f(i)
+			}
+		}()
+	}
+
+	for w := 0; w < 4; w++ {
+		run(func(i int) {
+			width, height := c.Size()
+			c.AddDelta(make([]byte, width*height*4), RGBA, "writer")
+		})
+		run(func(i int) {
+			c.AddPixels([]Pixel{{X: i % 8, Y: i % 8, R: 255, Source: "pixels"}}, []Run{{X: 0, Y: i % 8, Length: 8, G: 255}})
+		})
+		run(func(i int) {
+			img, err := c.GetImage(time.Now())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if img.Rect.Dx() < 8 || img.Rect.Dy() < 8 {
+				t.Errorf("Unexpected image size %v", img.Rect)
+			}
+		})
+	}
+	run(func(i int) {
+		width, height := c.Size()
+		c.SetOverlayImage(image.NewRGBA(image.Rect(0, 0, width, height)))
+	})
+	run(func(i int) {
+		c.Resize(8+i%16, 8+i%8, ResizePolicy(i%3))
+	})
+	wg.Wait()
+}
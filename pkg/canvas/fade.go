@@ -0,0 +1,100 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// FadeFunc returns the displayed color of a pixel that was set to r, g, b age
+// nanoseconds ago. Timeout is the pixel timeout of the canvas.
+type FadeFunc func(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8)
+
+// fadeFuncs holds the built-in fade curves by name, ParseFadeFunc looks them
+// up.
+var fadeFuncs = map[string]FadeFunc{
+	"linear":      FadeLinear,
+	"exponential": FadeExponential,
+	"step":        FadeStep,
+	"easeout":     FadeEaseOut,
+	"persistent":  FadePersistent,
+	"desaturate":  FadeDesaturate,
+}
+
+func ParseFadeFunc(name string) (FadeFunc, error) {
+	f, ok := fadeFuncs[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(fadeFuncs))
+		for n := range fadeFuncs {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("Unknown fade %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return f, nil
+}
+
+// progress returns how far a pixel is into its timeout, from 0 to 1.
+func progress(age uint64, timeout uint64) float32 {
+	if timeout == 0 || age >= timeout {
+		return 1.0
+	}
+	return float32(age) / float32(timeout)
+}
+
+func scale(r, g, b uint8, fac float32) (uint8, uint8, uint8) {
+	if fac <= 0.0 {
+		return 0, 0, 0
+	}
+	return uint8(fac * float32(r)), uint8(fac * float32(g)), uint8(fac * float32(b))
+}
+
+// FadeLinear dims pixels linearly to black over the timeout.
+func FadeLinear(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	return scale(r, g, b, 1.0-progress(age, timeout))
+}
+
+// FadeExponential dims pixels quickly at first and then slowly, reaching black
+// at the timeout.
+func FadeExponential(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	t := progress(age, timeout)
+	if t >= 1.0 {
+		return 0, 0, 0
+	}
+	return scale(r, g, b, float32(math.Exp(-5.0*float64(t))))
+}
+
+// FadeStep shows pixels at full brightness until the timeout and then drops
+// them to black.
+func FadeStep(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	if age >= timeout {
+		return 0, 0, 0
+	}
+	return r, g, b
+}
+
+// FadeEaseOut keeps pixels bright for most of the timeout and dims them
+// quickly towards the end.
+func FadeEaseOut(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	t := progress(age, timeout)
+	return scale(r, g, b, 1.0-t*t)
+}
+
+// FadePersistent never fades, pixels stay until they are painted over.
+func FadePersistent(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	return r, g, b
+}
+
+// FadeDesaturate turns pixels gray over the first half of the timeout and
+// fades the gray to black over the second half.
+func FadeDesaturate(r, g, b uint8, age uint64, timeout uint64) (uint8, uint8, uint8) {
+	t := progress(age, timeout)
+	gray := 0.299*float32(r) + 0.587*float32(g) + 0.114*float32(b)
+	if t < 0.5 {
+		s := t * 2.0
+		return uint8(float32(r) + (gray-float32(r))*s), uint8(float32(g) + (gray-float32(g))*s), uint8(float32(b) + (gray-float32(b))*s)
+	}
+	v := uint8(gray * (1.0 - (t-0.5)*2.0))
+	return v, v, v
+}
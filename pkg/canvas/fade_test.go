@@ -0,0 +1,58 @@
+package canvas
+
+import (
+	"testing"
+)
+
+type rgb struct {
+	r, g, b uint8
+}
+
+func TestFadeFuncs(t *testing.T) {
+	full := rgb{200, 100, 50}
+	black := rgb{}
+	// Ages are 0, half the timeout, the timeout and past it, the last result
+	// is for a timeout of 0
+	tests := []struct {
+		name     string
+		expected [5]rgb
+	}{
+		{"linear", [5]rgb{full, {100, 50, 25}, black, black, black}},
+		{"exponential", [5]rgb{full, {16, 8, 4}, black, black, black}},
+		{"step", [5]rgb{full, full, black, black, black}},
+		{"easeout", [5]rgb{full, {150, 75, 37}, black, black, black}},
+		{"persistent", [5]rgb{full, full, full, full, full}},
+		{"desaturate", [5]rgb{full, {124, 124, 124}, black, black, black}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fade, err := ParseFadeFunc(test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, at := range []struct{ age, timeout uint64 }{{0, 1000}, {500, 1000}, {1000, 1000}, {2000, 1000}, {0, 0}} {
+				r, g, b := fade(full.r, full.g, full.b, at.age, at.timeout)
+				if (rgb{r, g, b}) != test.expected[i] {
+					t.Errorf("Age %d of %d is %v, want %v", at.age, at.timeout, rgb{r, g, b}, test.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFadeDesaturateHalfway(t *testing.T) {
+	// A quarter into the timeout the color is halfway to gray
+	r, g, b := FadeDesaturate(200, 100, 50, 250, 1000)
+	if (rgb{r, g, b}) != (rgb{162, 112, 87}) {
+		t.Errorf("Desaturated to %v", rgb{r, g, b})
+	}
+}
+
+func TestParseFadeFunc(t *testing.T) {
+	if _, err := ParseFadeFunc("Linear"); err != nil {
+		t.Errorf("Fade names are case sensitive: %v", err)
+	}
+	if _, err := ParseFadeFunc("sparkle"); err == nil {
+		t.Errorf("Parsed an unknown fade")
+	}
+}
@@ -0,0 +1,99 @@
+package canvas
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxSources bounds the source table, it is compacted to the sources still
+// owning pixels once it grows beyond this.
+const maxSources = 1 << 16
+
+// MaxSourceLength is the longest source a pixel can be attributed to, longer
+// sources make a delta invalid.
+const MaxSourceLength = 1<<16 - 1
+
+// PixelInfo describes the stored state of a single pixel.
+type PixelInfo struct {
+	R, G, B     uint8
+	LastUpdated time.Time
+	Source      string
+}
+
+func validateSource(source string) error {
+	if len(source) > MaxSourceLength {
+		return fmt.Errorf("%w: source of %d bytes exceeds %d bytes", ErrInvalidDelta, len(source), MaxSourceLength)
+	}
+	return nil
+}
+
+// internSource returns the owner plane index of source, 0 means no source.
+// Must be called with the write lock held.
+func (c *Canvas) internSource(source string) uint32 {
+	if source == "" {
+		return 0
+	}
+	if i, ok := c.sourceIndex[source]; ok {
+		return i
+	}
+	if len(c.sources) >= c.compactAt {
+		c.compactSources()
+	}
+	i := uint32(len(c.sources))
+	c.sources = append(c.sources, source)
+	c.sourceIndex[source] = i
+	return i
+}
+
+// compactSources drops sources that no longer own any pixel.
+func (c *Canvas) compactSources() {
+	remap := make(map[uint32]uint32)
+	sources := []string{""}
+	sourceIndex := make(map[string]uint32)
+	for i, o := range c.Owner {
+		if o == 0 {
+			continue
+		}
+		n, ok := remap[o]
+		if !ok {
+			n = uint32(len(sources))
+			remap[o] = n
+			sources = append(sources, c.sources[o])
+			sourceIndex[c.sources[o]] = n
+		}
+		c.Owner[i] = n
+	}
+	c.sources = sources
+	c.sourceIndex = sourceIndex
+	c.updateCompactAt()
+}
+
+// updateCompactAt avoids compacting over and over while most sources are
+// still live.
+func (c *Canvas) updateCompactAt() {
+	c.compactAt = maxSources
+	if 2*len(c.sources) > c.compactAt {
+		c.compactAt = 2 * len(c.sources)
+	}
+}
+
+// GetPixelInfo returns the color, update time and source of a pixel.
+func (c *Canvas) GetPixelInfo(x int, y int) (PixelInfo, error) {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+	if x < 0 || y < 0 || x >= c.Width || y >= c.Height {
+		return PixelInfo{}, fmt.Errorf("Pixel (%d, %d) outside of %dx%d canvas", x, y, c.Width, c.Height)
+	}
+
+	i := y*c.Width + x
+	info := PixelInfo{
+		R:      c.R[i],
+		G:      c.G[i],
+		B:      c.B[i],
+		Source: c.sources[c.Owner[i]],
+	}
+	if c.LastUpdated[i] > 0 {
+		info.LastUpdated = time.Unix(0, int64(c.LastUpdated[i]))
+	}
+	return info, nil
+}
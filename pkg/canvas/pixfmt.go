@@ -0,0 +1,78 @@
+package canvas
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidDelta is wrapped by every error caused by a malformed delta.
+var ErrInvalidDelta = errors.New("invalid delta")
+
+// PixelFormat is the byte layout of a full-canvas delta image.
+type PixelFormat int
+
+const (
+	BGRA PixelFormat = iota
+	RGBA
+	RGB24
+	RGB565
+)
+
+func (f PixelFormat) String() string {
+	switch f {
+	case BGRA:
+		return "BGRA"
+	case RGBA:
+		return "RGBA"
+	case RGB24:
+		return "RGB24"
+	case RGB565:
+		return "RGB565"
+	}
+	return fmt.Sprintf("PixelFormat(%d)", int(f))
+}
+
+// BytesPerPixel returns the size of a single pixel, or 0 for unknown formats.
+func (f PixelFormat) BytesPerPixel() int {
+	switch f {
+	case BGRA, RGBA:
+		return 4
+	case RGB24:
+		return 3
+	case RGB565:
+		return 2
+	}
+	return 0
+}
+
+// DecodePixel returns the color of the pixel at the start of p and whether it
+// is set. Formats with alpha are set when alpha is non-zero, formats without
+// alpha treat black as unchanged. An all zero pixel is never set.
+func (f PixelFormat) DecodePixel(p []byte) (r, g, b uint8, set bool) {
+	switch f {
+	case BGRA:
+		return p[2], p[1], p[0], p[3] > 0
+	case RGBA:
+		return p[0], p[1], p[2], p[3] > 0
+	case RGB24:
+		r, g, b = p[0], p[1], p[2]
+	case RGB565:
+		v := uint16(p[0]) | uint16(p[1])<<8
+		r5, g6, b5 := uint8(v>>11)&0x1f, uint8(v>>5)&0x3f, uint8(v)&0x1f
+		r, g, b = r5<<3|r5>>2, g6<<2|g6>>4, b5<<3|b5>>2
+	}
+	return r, g, b, r|g|b != 0
+}
+
+// ValidateDelta checks that a full-canvas delta image has the size the format
+// requires for a width x height canvas.
+func ValidateDelta(deltaImage []byte, format PixelFormat, width int, height int) error {
+	bpp := format.BytesPerPixel()
+	if bpp == 0 {
+		return fmt.Errorf("%w: unsupported pixel format %v", ErrInvalidDelta, format)
+	}
+	if expected := width * height * bpp; len(deltaImage) != expected {
+		return fmt.Errorf("%w: expected %d bytes of %v for %dx%d, got %d", ErrInvalidDelta, expected, format, width, height, len(deltaImage))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package canvas
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodePixel(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  PixelFormat
+		pixel   []byte
+		r, g, b uint8
+		set     bool
+	}{
+		{"bgra", BGRA, []byte{1, 2, 3, 255}, 3, 2, 1, true},
+		{"bgra transparent", BGRA, []byte{1, 2, 3, 0}, 3, 2, 1, false},
+		{"bgra opaque black", BGRA, []byte{0, 0, 0, 255}, 0, 0, 0, true},
+		{"rgba", RGBA, []byte{1, 2, 3, 1}, 1, 2, 3, true},
+		{"rgba transparent", RGBA, []byte{1, 2, 3, 0}, 1, 2, 3, false},
+		{"rgb24", RGB24, []byte{1, 2, 3}, 1, 2, 3, true},
+		{"rgb24 black", RGB24, []byte{0, 0, 0}, 0, 0, 0, false},
+		{"rgb565 white", RGB565, []byte{0xff, 0xff}, 255, 255, 255, true},
+		{"rgb565 red", RGB565, []byte{0x00, 0xf8}, 255, 0, 0, true},
+		{"rgb565 green", RGB565, []byte{0xe0, 0x07}, 0, 255, 0, true},
+		{"rgb565 blue", RGB565, []byte{0x1f, 0x00}, 0, 0, 255, true},
+		{"rgb565 lowest bits", RGB565, []byte{0x21, 0x08}, 8, 4, 8, true},
+		{"rgb565 mid", RGB565, []byte{0xef, 0x7b}, 123, 125, 123, true},
+		{"rgb565 black", RGB565, []byte{0x00, 0x00}, 0, 0, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, g, b, set := test.format.DecodePixel(test.pixel)
+			if r != test.r || g != test.g || b != test.b || set != test.set {
+				t.Errorf("DecodePixel(%v) = %d, %d, %d, %v, want %d, %d, %d, %v", test.pixel, r, g, b, set, test.r, test.g, test.b, test.set)
+			}
+		})
+	}
+}
+
+func TestValidateDelta(t *testing.T) {
+	tests := []struct {
+		name   string
+		format PixelFormat
+		size   int
+		valid  bool
+	}{
+		{"bgra", BGRA, 4 * 6, true},
+		{"rgba", RGBA, 4 * 6, true},
+		{"rgb24", RGB24, 3 * 6, true},
+		{"rgb565", RGB565, 2 * 6, true},
+		{"short", RGBA, 4*6 - 1, false},
+		{"long", RGB24, 3*6 + 3, false},
+		{"rgba sized as rgb24", RGBA, 3 * 6, false},
+		{"empty", RGB565, 0, false},
+		{"unknown format", PixelFormat(42), 6, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDelta(make([]byte, test.size), test.format, 3, 2)
+			if test.valid && err != nil {
+				t.Errorf("ValidateDelta returned %v", err)
+			}
+			if !test.valid && !errors.Is(err, ErrInvalidDelta) {
+				t.Errorf("ValidateDelta returned %v, want ErrInvalidDelta", err)
+			}
+		})
+	}
+}
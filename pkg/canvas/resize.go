@@ -0,0 +1,89 @@
+package canvas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResizePolicy decides what happens to existing pixels when resizing.
+type ResizePolicy int
+
+const (
+	// ResizeCrop keeps pixels at their coordinates, cutting or extending the
+	// canvas at the right and bottom.
+	ResizeCrop ResizePolicy = iota
+	// ResizePad keeps the content centered, cutting or extending the canvas
+	// equally on opposite sides.
+	ResizePad
+	// ResizeScale stretches the content to the new size.
+	ResizeScale
+)
+
+func (p ResizePolicy) String() string {
+	switch p {
+	case ResizeCrop:
+		return "crop"
+	case ResizePad:
+		return "pad"
+	case ResizeScale:
+		return "scale"
+	}
+	return fmt.Sprintf("ResizePolicy(%d)", int(p))
+}
+
+func ParseResizePolicy(name string) (ResizePolicy, error) {
+	for _, p := range []ResizePolicy{ResizeCrop, ResizePad, ResizeScale} {
+		if strings.ToLower(name) == p.String() {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown resize policy %q, expected one of crop, pad, scale", name)
+}
+
+// Size returns the current width and height of the canvas.
+func (c *Canvas) Size() (int, int) {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+	return c.Width, c.Height
+}
+
+// Resize changes the dimensions of the canvas, moving the existing pixels
+// according to policy. The overlay is removed, as it no longer fits.
+func (c *Canvas) Resize(width int, height int, policy ResizePolicy) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("Invalid canvas size %dx%d", width, height)
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	var source func(x, y int) (int, int)
+	switch policy {
+	case ResizeCrop:
+		source = func(x, y int) (int, int) { return x, y }
+	case ResizePad:
+		dx, dy := (c.Width-width)/2, (c.Height-height)/2
+		source = func(x, y int) (int, int) { return x + dx, y + dy }
+	case ResizeScale:
+		source = func(x, y int) (int, int) { return x * c.Width / width, y * c.Height / height }
+	default:
+		return fmt.Errorf("Unknown resize policy %v", policy)
+	}
+
+	size := width * height
+	R, G, B, lastUpdated, owner := make([]uint8, size), make([]uint8, size), make([]uint8, size), make([]uint64, size), make([]uint32, size)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			sx, sy := source(x, y)
+			if sx < 0 || sy < 0 || sx >= c.Width || sy >= c.Height {
+				continue
+			}
+			i, si := y*width+x, sy*c.Width+sx
+			R[i], G[i], B[i], lastUpdated[i], owner[i] = c.R[si], c.G[si], c.B[si], c.LastUpdated[si], c.Owner[si]
+		}
+	}
+
+	c.R, c.G, c.B, c.LastUpdated, c.Owner = R, G, B, lastUpdated, owner
+	c.Width, c.Height = width, height
+	c.overlay = nil
+	return nil
+}
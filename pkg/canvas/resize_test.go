@@ -0,0 +1,104 @@
+package canvas
+
+import (
+	"fmt"
+	"image"
+	"testing"
+)
+
+// numberedCanvas returns a 4x4 canvas whose pixels have a red value of 1 to 16
+// in row order and are owned by a source named after it.
+func numberedCanvas(t *testing.T) *Canvas {
+	c := NewCanvas(4, 4, 1000000000)
+	pixels := make([]Pixel, 0, 16)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			n := y*4 + x + 1
+			pixels = append(pixels, Pixel{X: x, Y: y, R: uint8(n), Source: fmt.Sprint(n)})
+		}
+	}
+	err := c.AddPixels(pixels, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		policy        ResizePolicy
+		width, height int
+		// Expected pixel numbers at coordinates, 0 for unset pixels
+		pixels map[image.Point]int
+	}{
+		{ResizeCrop, 6, 5, map[image.Point]int{{0, 0}: 1, {3, 3}: 16, {4, 0}: 0, {0, 4}: 0, {5, 4}: 0}},
+		{ResizeCrop, 2, 3, map[image.Point]int{{0, 0}: 1, {1, 1}: 6, {1, 2}: 10}},
+		{ResizePad, 6, 6, map[image.Point]int{{0, 0}: 0, {1, 1}: 1, {4, 1}: 4, {4, 4}: 16, {5, 5}: 0, {0, 3}: 0}},
+		{ResizePad, 2, 2, map[image.Point]int{{0, 0}: 6, {1, 0}: 7, {0, 1}: 10, {1, 1}: 11}},
+		{ResizePad, 3, 7, map[image.Point]int{{0, 0}: 0, {0, 1}: 1, {2, 1}: 3, {2, 4}: 15, {0, 5}: 0}},
+		{ResizeScale, 8, 8, map[image.Point]int{{0, 0}: 1, {1, 1}: 1, {2, 2}: 6, {7, 0}: 4, {7, 7}: 16}},
+		{ResizeScale, 2, 2, map[image.Point]int{{0, 0}: 1, {1, 0}: 3, {0, 1}: 9, {1, 1}: 11}},
+		{ResizeScale, 8, 2, map[image.Point]int{{1, 0}: 1, {2, 0}: 2, {7, 1}: 12}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%v %dx%d", test.policy, test.width, test.height), func(t *testing.T) {
+			c := numberedCanvas(t)
+			err := c.Resize(test.width, test.height, test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if width, height := c.Size(); width != test.width || height != test.height {
+				t.Fatalf("Canvas is %dx%d after resizing", width, height)
+			}
+			for p, n := range test.pixels {
+				info, err := c.GetPixelInfo(p.X, p.Y)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if int(info.R) != n {
+					t.Errorf("Pixel %v is %d, want %d", p, info.R, n)
+				}
+				if n > 0 && info.Source != fmt.Sprint(n) {
+					t.Errorf("Pixel %v is owned by %q, want %d", p, info.Source, n)
+				}
+				if n == 0 && !info.LastUpdated.IsZero() {
+					t.Errorf("New pixel %v is set", p)
+				}
+			}
+		})
+	}
+}
+
+func TestResizeInvalid(t *testing.T) {
+	c := numberedCanvas(t)
+	for _, test := range []struct {
+		width, height int
+		policy        ResizePolicy
+	}{{0, 4, ResizeCrop}, {4, -1, ResizeCrop}, {4, 4, ResizePolicy(3)}} {
+		if c.Resize(test.width, test.height, test.policy) == nil {
+			t.Errorf("Resized to %dx%d with %v", test.width, test.height, test.policy)
+		}
+	}
+	if width, height := c.Size(); width != 4 || height != 4 {
+		t.Errorf("Failed resizes changed the canvas to %dx%d", width, height)
+	}
+}
+
+func TestResizeRemovesOverlay(t *testing.T) {
+	c := numberedCanvas(t)
+	err := c.SetOverlayImage(image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Resize(2, 2, ResizeCrop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := c.GetImage(c.clock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 2, 2) || c.overlay != nil {
+		t.Errorf("Overlay was kept after resizing")
+	}
+}
@@ -0,0 +1,168 @@
+package canvas
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A snapshot stores the canvas planes in big endian:
+//
+//	magic       [4]byte "SXPS"
+//	version     uint16
+//	width       uint32
+//	height      uint32
+//	saved       int64, nanoseconds since the unix epoch
+//	R, G, B     width*height bytes each
+//	LastUpdated width*height uint64
+//	Owner       width*height uint32, since version 2
+//	sources     uint32 count, then per source a uint16 length and the bytes,
+//	            since version 2
+//
+// LastUpdated holds wall-clock times, so a restored canvas keeps fading pixels
+// as if the renderer had never been stopped.
+const (
+	snapshotMagic   = "SXPS"
+	snapshotVersion = 2
+)
+
+// maxSnapshotPixels keeps a corrupt header from allocating arbitrary amounts of
+// memory, it allows canvases far beyond any display.
+const maxSnapshotPixels = 1 << 26
+
+type snapshotHeader struct {
+	Magic   [4]byte
+	Version uint16
+	Width   uint32
+	Height  uint32
+	Saved   int64
+}
+
+// WriteSnapshot writes the current pixel state to w.
+func (c *Canvas) WriteSnapshot(w io.Writer) error {
+	c.mut.RLock()
+	defer c.mut.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	header := snapshotHeader{
+		Version: snapshotVersion,
+		Width:   uint32(c.Width),
+		Height:  uint32(c.Height),
+		Saved:   time.Now().UnixNano(),
+	}
+	copy(header.Magic[:], snapshotMagic)
+
+	for _, data := range []interface{}{header, c.R, c.G, c.B, c.LastUpdated, c.Owner, uint32(len(c.sources))} {
+		err := binary.Write(bw, binary.BigEndian, data)
+		if err != nil {
+			return err
+		}
+	}
+	for _, source := range c.sources {
+		// Deltas with longer sources are rejected, so this never truncates
+		if len(source) > MaxSourceLength {
+			return fmt.Errorf("Source of %d bytes does not fit in a snapshot", len(source))
+		}
+		err := binary.Write(bw, binary.BigEndian, uint16(len(source)))
+		if err != nil {
+			return err
+		}
+		_, err = bw.WriteString(source)
+		if err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadSnapshot replaces the pixel state with a snapshot read from r. The canvas
+// takes the dimensions of the snapshot, which differ from the configured ones
+// after a resize at runtime. The overlay is cleared when the size changes.
+func (c *Canvas) ReadSnapshot(r io.Reader) error {
+	br := bufio.NewReader(r)
+	var header snapshotHeader
+	err := binary.Read(br, binary.BigEndian, &header)
+	if err != nil {
+		return err
+	}
+	if string(header.Magic[:]) != snapshotMagic {
+		return fmt.Errorf("Not a canvas snapshot")
+	}
+	if header.Version < 1 || header.Version > snapshotVersion {
+		return fmt.Errorf("Unsupported snapshot version %d", header.Version)
+	}
+	width, height := int(header.Width), int(header.Height)
+	if width <= 0 || height <= 0 || width*height > maxSnapshotPixels {
+		return fmt.Errorf("Invalid snapshot size %dx%d", width, height)
+	}
+
+	size := width * height
+	R, G, B, lastUpdated := make([]uint8, size), make([]uint8, size), make([]uint8, size), make([]uint64, size)
+	for _, data := range []interface{}{R, G, B, lastUpdated} {
+		err := binary.Read(br, binary.BigEndian, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	owner, sources := make([]uint32, size), []string{""}
+	if header.Version >= 2 {
+		owner, sources, err = readSources(br, size)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.Width != width || c.Height != height {
+		c.Width, c.Height = width, height
+		c.overlay = nil
+	}
+	c.R, c.G, c.B, c.LastUpdated, c.Owner = R, G, B, lastUpdated, owner
+	c.sources = sources
+	c.sourceIndex = make(map[string]uint32, len(sources))
+	for i, source := range sources[1:] {
+		c.sourceIndex[source] = uint32(i + 1)
+	}
+	c.updateCompactAt()
+	return nil
+}
+
+func readSources(br *bufio.Reader, size int) ([]uint32, []string, error) {
+	owner := make([]uint32, size)
+	var count uint32
+	for _, data := range []interface{}{owner, &count} {
+		err := binary.Read(br, binary.BigEndian, data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if count == 0 {
+		return nil, nil, fmt.Errorf("Snapshot has an empty source table")
+	}
+
+	sources := make([]string, count)
+	for i := range sources {
+		var length uint16
+		err := binary.Read(br, binary.BigEndian, &length)
+		if err != nil {
+			return nil, nil, err
+		}
+		buf := make([]byte, length)
+		_, err = io.ReadFull(br, buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		sources[i] = string(buf)
+	}
+
+	for _, o := range owner {
+		if o >= count {
+			return nil, nil, fmt.Errorf("Snapshot owner %d outside of source table", o)
+		}
+	}
+	return owner, sources, nil
+}
@@ -0,0 +1,180 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testCanvas(t *testing.T) *Canvas {
+	c := NewCanvas(3, 2, 1000000000)
+	err := c.AddPixels([]Pixel{{X: 0, Y: 0, R: 1, G: 2, B: 3, Source: "2001:db8::1"}, {X: 2, Y: 1, R: 4, G: 5, B: 6}}, []Run{{X: 0, Y: 1, Length: 2, R: 7, Source: "2001:db8::2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func comparePixels(t *testing.T, got *Canvas, want *Canvas, sources bool) {
+	t.Helper()
+	for y := 0; y < want.Height; y++ {
+		for x := 0; x < want.Width; x++ {
+			g, err := got.GetPixelInfo(x, y)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, _ := want.GetPixelInfo(x, y)
+			if !sources {
+				w.Source = ""
+			}
+			if !reflect.DeepEqual(g, w) {
+				t.Errorf("Pixel (%d, %d) is %+v, want %+v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	c := testCanvas(t)
+	buf := new(bytes.Buffer)
+	err := c.WriteSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewCanvas(3, 2, 1000000000)
+	err = restored.ReadSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comparePixels(t, restored, c, true)
+
+	// Restored sources are interned again for new deltas
+	err = restored.AddPixels([]Pixel{{X: 1, Y: 0, R: 9, Source: "2001:db8::1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.sources) != 3 {
+		t.Errorf("Source table is %q after reusing a restored source", restored.sources)
+	}
+}
+
+// writeV1Snapshot writes a snapshot as version 1 did, without owners and
+// sources.
+func writeV1Snapshot(t *testing.T, c *Canvas) *bytes.Buffer {
+	buf := new(bytes.Buffer)
+	header := snapshotHeader{Version: 1, Width: uint32(c.Width), Height: uint32(c.Height), Saved: time.Now().UnixNano()}
+	copy(header.Magic[:], snapshotMagic)
+	for _, data := range []interface{}{header, c.R, c.G, c.B, c.LastUpdated} {
+		err := binary.Write(buf, binary.BigEndian, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf
+}
+
+func TestSnapshotReadsVersion1(t *testing.T) {
+	c := testCanvas(t)
+	restored := NewCanvas(3, 2, 1000000000)
+	err := restored.ReadSnapshot(writeV1Snapshot(t, c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	comparePixels(t, restored, c, false)
+
+	// Saving again upgrades the snapshot to the current version
+	buf := new(bytes.Buffer)
+	err = restored.WriteSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version := binary.BigEndian.Uint16(buf.Bytes()[4:]); version != snapshotVersion {
+		t.Errorf("Snapshot was written as version %d", version)
+	}
+	again := NewCanvas(3, 2, 1000000000)
+	err = again.ReadSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comparePixels(t, again, c, false)
+}
+
+func TestSnapshotRejectsInvalid(t *testing.T) {
+	c := testCanvas(t)
+	buf := new(bytes.Buffer)
+	err := c.WriteSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+
+	badMagic := append([]byte{}, valid...)
+	badMagic[0] = 'X'
+	badVersion := append([]byte{}, valid...)
+	binary.BigEndian.PutUint16(badVersion[4:], snapshotVersion+1)
+	for name, b := range map[string][]byte{"magic": badMagic, "version": badVersion, "truncated": valid[:len(valid)-1]} {
+		restored := NewCanvas(3, 2, 1000000000)
+		if err := restored.ReadSnapshot(bytes.NewReader(b)); err == nil {
+			t.Errorf("Accepted snapshot with invalid %s", name)
+		}
+	}
+}
+
+func TestLongSourcesAreRejected(t *testing.T) {
+	c := NewCanvas(2, 2, 1000000000)
+	long := string(make([]byte, MaxSourceLength+1))
+	err := c.AddPixels([]Pixel{{X: 0, Y: 0, R: 1, Source: long}}, nil)
+	if !errors.Is(err, ErrInvalidDelta) {
+		t.Errorf("AddPixels returned %v for a %d byte source", err, len(long))
+	}
+	err = c.AddDelta(make([]byte, 2*2*3), RGB24, long)
+	if !errors.Is(err, ErrInvalidDelta) {
+		t.Errorf("AddDelta returned %v for a %d byte source", err, len(long))
+	}
+
+	longest := long[:MaxSourceLength]
+	err = c.AddPixels([]Pixel{{X: 1, Y: 1, R: 1, Source: longest}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	err = c.WriteSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := NewCanvas(2, 2, 1000000000)
+	err = restored.ReadSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := restored.GetPixelInfo(1, 1); info.Source != longest {
+		t.Errorf("Restored a %d byte source as %d bytes", len(longest), len(info.Source))
+	}
+}
+
+func TestSnapshotRestoresSize(t *testing.T) {
+	c := testCanvas(t)
+	err := c.Resize(5, 4, ResizePad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	err = c.WriteSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewCanvas(3, 2, 1000000000)
+	err = restored.ReadSnapshot(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width, height := restored.Size(); width != 5 || height != 4 {
+		t.Fatalf("Restored canvas is %dx%d, want 5x4", width, height)
+	}
+	comparePixels(t, restored, c, true)
+}
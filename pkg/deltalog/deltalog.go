@@ -0,0 +1,262 @@
+package deltalog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+// A delta log is a directory of segment files named after the timestamp of
+// their first record, so they sort chronologically. Every segment starts with
+// a magic and version byte followed by records, each a uvarint length and a
+// marshaled DeltaRecord. The first record of a segment is a resize record
+// holding the canvas size, so segments can be replayed on their own.
+const (
+	segmentMagic   = "SXPD"
+	segmentVersion = 1
+	segmentPattern = "deltas-*.log"
+)
+
+// MaxRecordSize bounds the length of a record, which keeps a corrupt length
+// from allocating arbitrary amounts of memory. Records hold a delta, so it
+// matches the largest message the renderer accepts.
+const MaxRecordSize = 64000000
+
+func segmentName(timestamp int64) string {
+	return fmt.Sprintf("deltas-%020d.log", timestamp)
+}
+
+// Writer appends records to a delta log, starting a new segment when the
+// current one exceeds maxSize bytes or is older than maxAge.
+type Writer struct {
+	dir        string
+	maxSize    int64
+	maxAge     time.Duration
+	canvasSize func() (int, int)
+	file       *os.File
+	buf        *bufio.Writer
+	size       int64
+	opened     time.Time
+	mut        sync.Mutex
+}
+
+// NewWriter creates a writer, a maxSize or maxAge of 0 disables that limit.
+// canvasSize is called when a segment is started to record the canvas size.
+func NewWriter(dir string, maxSize int64, maxAge time.Duration, canvasSize func() (int, int)) (*Writer, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		dir:        dir,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		canvasSize: canvasSize,
+	}, nil
+}
+
+func (w *Writer) Append(rec *pb.DeltaRecord) error {
+	data, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxRecordSize {
+		return fmt.Errorf("Delta record of %d bytes exceeds %d bytes", len(data), MaxRecordSize)
+	}
+
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	if w.file == nil || (w.maxSize > 0 && w.size >= w.maxSize) || (w.maxAge > 0 && time.Since(w.opened) >= w.maxAge) {
+		err := w.rotate(rec.GetTimestamp())
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.write(data)
+}
+
+func (w *Writer) write(data []byte) error {
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], uint64(len(data)))
+	w.buf.Write(length[:n])
+	w.buf.Write(data)
+	err := w.buf.Flush()
+	if err != nil {
+		return err
+	}
+	w.size += int64(n + len(data))
+	return nil
+}
+
+func (w *Writer) rotate(timestamp int64) error {
+	err := w.closeSegment()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(w.dir, segmentName(timestamp)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.buf = bufio.NewWriter(f)
+	w.opened = time.Now()
+	w.buf.WriteString(segmentMagic)
+	w.buf.WriteByte(segmentVersion)
+	w.size = int64(len(segmentMagic) + 1)
+
+	width, height := w.canvasSize()
+	data, err := proto.Marshal(&pb.DeltaRecord{
+		Timestamp: timestamp,
+		Resize:    &pb.ResizeRequest{Width: uint32(width), Height: uint32(height), Policy: pb.ResizePolicy_CROP},
+	})
+	if err != nil {
+		return err
+	}
+	return w.write(data)
+}
+
+func (w *Writer) closeSegment() error {
+	if w.file == nil {
+		return nil
+	}
+	err := w.buf.Flush()
+	if cerr := w.file.Close(); err == nil {
+		err = cerr
+	}
+	w.file = nil
+	w.buf = nil
+	return err
+}
+
+func (w *Writer) Close() error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return w.closeSegment()
+}
+
+// Segments returns the segment files of a delta log in chronological order.
+func Segments(dir string) ([]string, error) {
+	segments, err := filepath.Glob(filepath.Join(dir, segmentPattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// Reader reads all records of a delta log in order. A record cut short at the
+// end of a segment, as left behind by a crash, ends that segment.
+type Reader struct {
+	segments []string
+	file     *os.File
+	buf      *bufio.Reader
+}
+
+func NewReader(dir string) (*Reader, error) {
+	segments, err := Segments(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{segments: segments}, nil
+}
+
+// Next returns the next record, or io.EOF after the last one.
+func (r *Reader) Next() (*pb.DeltaRecord, error) {
+	for {
+		if r.file == nil {
+			if len(r.segments) == 0 {
+				return nil, io.EOF
+			}
+			err := r.open(r.segments[0])
+			r.segments = r.segments[1:]
+			if err == io.EOF {
+				// Empty segment of a writer that stopped right after creating it
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rec, err := r.readRecord()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			r.file.Close()
+			r.file = nil
+			continue
+		}
+		return rec, err
+	}
+}
+
+func (r *Reader) open(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	buf := bufio.NewReader(f)
+
+	var header [len(segmentMagic) + 1]byte
+	_, err = io.ReadFull(buf, header[:])
+	if err == io.EOF {
+		f.Close()
+		return err
+	}
+	if err != nil || string(header[:len(segmentMagic)]) != segmentMagic {
+		f.Close()
+		return fmt.Errorf("%s is not a delta log segment", path)
+	}
+	if header[len(segmentMagic)] != segmentVersion {
+		f.Close()
+		return fmt.Errorf("%s has unsupported version %d", path, header[len(segmentMagic)])
+	}
+
+	r.file = f
+	r.buf = buf
+	return nil
+}
+
+func (r *Reader) readRecord() (*pb.DeltaRecord, error) {
+	length, err := binary.ReadUvarint(r.buf)
+	if err != nil {
+		return nil, err
+	}
+	if length > MaxRecordSize {
+		return nil, fmt.Errorf("Corrupt delta record: length %d exceeds %d bytes", length, MaxRecordSize)
+	}
+	data := make([]byte, length)
+	_, err = io.ReadFull(r.buf, data)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	rec := &pb.DeltaRecord{}
+	err = proto.Unmarshal(data, rec)
+	if err != nil {
+		return nil, fmt.Errorf("Corrupt delta record: %v", err)
+	}
+	return rec, nil
+}
+
+func (r *Reader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
@@ -0,0 +1,175 @@
+package deltalog
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+func writeLog(t *testing.T, dir string, timestamps ...int64) {
+	t.Helper()
+	w, err := NewWriter(dir, 0, 0, func() (int, int) { return 16, 9 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ts := range timestamps {
+		err := w.Append(&pb.DeltaRecord{Timestamp: ts, Source: "[2001:db8::1]:1234", Delta: &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: 1, Y: 2, Rgb: 0xff0000}}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readAll(t *testing.T, dir string) ([]int64, error) {
+	t.Helper()
+	r, err := NewReader(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	timestamps := make([]int64, 0)
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return timestamps, nil
+		}
+		if err != nil {
+			return timestamps, err
+		}
+		if rec.GetResize() == nil {
+			timestamps = append(timestamps, rec.GetTimestamp())
+		}
+	}
+}
+
+func equal(a []int64, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "deltalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSegmentsStartWithCanvasSize(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeLog(t, dir, 1, 2)
+	writeLog(t, dir, 3)
+
+	r, err := NewReader(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	resizes := make([]int64, 0)
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resize := rec.GetResize(); resize != nil {
+			if resize.GetWidth() != 16 || resize.GetHeight() != 9 || resize.GetPolicy() != pb.ResizePolicy_CROP {
+				t.Errorf("Unexpected canvas size record %v", resize)
+			}
+			resizes = append(resizes, rec.GetTimestamp())
+		}
+	}
+	if !equal(resizes, []int64{1, 3}) {
+		t.Errorf("Segments started with canvas size records at %v", resizes)
+	}
+}
+
+func TestTruncatedLastRecord(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeLog(t, dir, 1, 2, 3)
+	writeLog(t, dir, 4, 5)
+
+	segments, err := Segments(dir)
+	if err != nil || len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %v (%v)", segments, err)
+	}
+	info, err := os.Stat(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cut the last record of the first segment short, as a crash would
+	for _, cut := range []int64{1, 5} {
+		err = os.Truncate(segments[0], info.Size()-cut)
+		if err != nil {
+			t.Fatal(err)
+		}
+		timestamps, err := readAll(t, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equal(timestamps, []int64{1, 2, 4, 5}) {
+			t.Errorf("Read %v after cutting %d bytes", timestamps, cut)
+		}
+	}
+}
+
+func TestEmptySegment(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeLog(t, dir, 1)
+	err := ioutil.WriteFile(filepath.Join(dir, segmentName(2)), nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeLog(t, dir, 3)
+
+	timestamps, err := readAll(t, dir)
+	if err != nil || !equal(timestamps, []int64{1, 3}) {
+		t.Errorf("Read %v (%v)", timestamps, err)
+	}
+}
+
+func TestCorruptRecordLength(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeLog(t, dir, 1)
+
+	segments, _ := Segments(dir)
+	f, err := os.OpenFile(segments[0], os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var length [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(length[:], 1<<40)
+	f.Write(length[:n])
+	f.Close()
+
+	timestamps, err := readAll(t, dir)
+	if err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Errorf("Read %v, expected a corrupt length error, got %v", timestamps, err)
+	}
+	if !equal(timestamps, []int64{1}) {
+		t.Errorf("Read %v before the corrupt record", timestamps)
+	}
+}
@@ -0,0 +1,317 @@
+package flood
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind distinguishes what an anomaly was detected for.
+type Kind int
+
+const (
+	Source Kind = iota
+	Region
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Source:
+		return "source"
+	case Region:
+		return "region"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Config holds the thresholds of a Detector.
+type Config struct {
+	// Window is the sliding window write rates are computed over, it is
+	// tracked in Buckets steps.
+	Window  time.Duration
+	Buckets int
+	// SourceShare flags a source prefix that wrote more than this fraction of
+	// the canvas pixels within the window.
+	SourceShare float64
+	// RegionSize is the edge length of the square regions, RegionRepaints
+	// flags a region written more than this many times its area within the
+	// window.
+	RegionSize     int
+	RegionRepaints float64
+}
+
+// Anomaly is a source or region exceeding its threshold. Value is the share of
+// the canvas for sources and the number of repaints for regions.
+type Anomaly struct {
+	Kind  Kind
+	Key   string
+	Rect  image.Rectangle
+	Value float64
+}
+
+// series counts writes in a ring of buckets, a bucket is valid while its epoch
+// is within the window.
+type series struct {
+	counts []int
+	epochs []int64
+}
+
+func newSeries(buckets int) *series {
+	return &series{counts: make([]int, buckets), epochs: make([]int64, buckets)}
+}
+
+func (s *series) add(epoch int64, n int) {
+	i := int(epoch % int64(len(s.counts)))
+	if s.epochs[i] != epoch {
+		s.epochs[i] = epoch
+		s.counts[i] = 0
+	}
+	s.counts[i] += n
+}
+
+func (s *series) total(epoch int64) int {
+	total := 0
+	for i, e := range s.epochs {
+		if epoch-e < int64(len(s.counts)) {
+			total += s.counts[i]
+		}
+	}
+	return total
+}
+
+// Detector computes per-source and per-region write rates over a sliding
+// window and flags those exceeding the configured thresholds.
+type Detector struct {
+	cfg         Config
+	bucket      time.Duration
+	bounds      image.Rectangle
+	regionsX    int
+	sources     map[string]*series
+	regions     map[int]*series
+	flagged     map[Kind]map[string]Anomaly
+	quarantined map[string]time.Time
+	peakShare   float64
+	peakRepaint float64
+	mut         sync.Mutex
+}
+
+func NewDetector(width int, height int, cfg Config) (*Detector, error) {
+	if cfg.Window <= 0 || cfg.Buckets <= 0 {
+		return nil, fmt.Errorf("Invalid flood window %v in %d buckets", cfg.Window, cfg.Buckets)
+	}
+	if cfg.RegionSize <= 0 {
+		return nil, fmt.Errorf("Invalid flood region size %d", cfg.RegionSize)
+	}
+	return &Detector{
+		cfg:         cfg,
+		bucket:      cfg.Window / time.Duration(cfg.Buckets),
+		bounds:      image.Rect(0, 0, width, height),
+		regionsX:    (width + cfg.RegionSize - 1) / cfg.RegionSize,
+		sources:     make(map[string]*series),
+		regions:     make(map[int]*series),
+		flagged:     map[Kind]map[string]Anomaly{Source: make(map[string]Anomaly), Region: make(map[string]Anomaly)},
+		quarantined: make(map[string]time.Time),
+	}, nil
+}
+
+// Resize adapts the detector to a resized canvas. Region rates are reset, as
+// the regions no longer cover the same pixels.
+func (d *Detector) Resize(width int, height int) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	d.bounds = image.Rect(0, 0, width, height)
+	d.regionsX = (width + d.cfg.RegionSize - 1) / d.cfg.RegionSize
+	d.regions = make(map[int]*series)
+	d.flagged[Region] = make(map[string]Anomaly)
+}
+
+// RegionIndexer returns a function mapping a pixel to the index of the region
+// containing it, for the current canvas size.
+func (d *Detector) RegionIndexer() func(x, y int) int {
+	d.mut.Lock()
+	regionsX := d.regionsX
+	d.mut.Unlock()
+	size := d.cfg.RegionSize
+	return func(x, y int) int {
+		return (y/size)*regionsX + x/size
+	}
+}
+
+func (d *Detector) regionRect(index int) image.Rectangle {
+	x, y := (index%d.regionsX)*d.cfg.RegionSize, (index/d.regionsX)*d.cfg.RegionSize
+	return image.Rect(x, y, x+d.cfg.RegionSize, y+d.cfg.RegionSize).Intersect(d.bounds)
+}
+
+func (d *Detector) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(d.bucket)
+}
+
+func (d *Detector) sourceAnomaly(key string, total int) (Anomaly, bool) {
+	share := float64(total) / float64(d.bounds.Dx()*d.bounds.Dy())
+	return Anomaly{Kind: Source, Key: key, Value: share}, share > d.cfg.SourceShare
+}
+
+func (d *Detector) regionAnomaly(index int, total int) (Anomaly, bool) {
+	rect := d.regionRect(index)
+	repaints := float64(total) / float64(rect.Dx()*rect.Dy())
+	return Anomaly{Kind: Region, Key: fmt.Sprintf("%d,%d", rect.Min.X, rect.Min.Y), Rect: rect, Value: repaints}, repaints > d.cfg.RegionRepaints
+}
+
+// Observe adds the pixels written per source prefix and per region index and
+// returns the anomalies that were newly flagged.
+func (d *Detector) Observe(now time.Time, sources map[string]int, regions map[int]int) []Anomaly {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	epoch := d.epoch(now)
+	flagged := make([]Anomaly, 0)
+
+	for key, n := range sources {
+		s, ok := d.sources[key]
+		if !ok {
+			s = newSeries(d.cfg.Buckets)
+			d.sources[key] = s
+		}
+		s.add(epoch, n)
+		a, over := d.sourceAnomaly(key, s.total(epoch))
+		if over && d.flag(a) {
+			flagged = append(flagged, a)
+		}
+	}
+
+	for index, n := range regions {
+		if d.regionRect(index).Empty() {
+			// Counted before a resize
+			continue
+		}
+		s, ok := d.regions[index]
+		if !ok {
+			s = newSeries(d.cfg.Buckets)
+			d.regions[index] = s
+		}
+		s.add(epoch, n)
+		a, over := d.regionAnomaly(index, s.total(epoch))
+		if over && d.flag(a) {
+			flagged = append(flagged, a)
+		}
+	}
+	return flagged
+}
+
+// flag records an anomaly and reports whether it is new.
+func (d *Detector) flag(a Anomaly) bool {
+	_, ok := d.flagged[a.Kind][a.Key]
+	d.flagged[a.Kind][a.Key] = a
+	return !ok
+}
+
+// Sweep recomputes the rates of all tracked sources and regions, forgets those
+// without writes in the window and returns the anomalies that were cleared.
+// Quarantines of cleared sources and expired quarantines are lifted.
+func (d *Detector) Sweep(now time.Time) []Anomaly {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	epoch := d.epoch(now)
+	cleared := make([]Anomaly, 0)
+	d.peakShare, d.peakRepaint = 0, 0
+
+	for key, s := range d.sources {
+		total := s.total(epoch)
+		if total == 0 {
+			delete(d.sources, key)
+		}
+		a, over := d.sourceAnomaly(key, total)
+		if a.Value > d.peakShare {
+			d.peakShare = a.Value
+		}
+		cleared = d.update(a, over, cleared)
+	}
+
+	for prefix, until := range d.quarantined {
+		if !now.Before(until) {
+			delete(d.quarantined, prefix)
+		}
+	}
+
+	for index, s := range d.regions {
+		total := s.total(epoch)
+		if total == 0 {
+			delete(d.regions, index)
+		}
+		a, over := d.regionAnomaly(index, total)
+		if a.Value > d.peakRepaint {
+			d.peakRepaint = a.Value
+		}
+		cleared = d.update(a, over, cleared)
+	}
+	return cleared
+}
+
+func (d *Detector) update(a Anomaly, over bool, cleared []Anomaly) []Anomaly {
+	if _, ok := d.flagged[a.Kind][a.Key]; !ok {
+		return cleared
+	}
+	if over {
+		d.flagged[a.Kind][a.Key] = a
+		return cleared
+	}
+	delete(d.flagged[a.Kind], a.Key)
+	if a.Kind == Source {
+		delete(d.quarantined, a.Key)
+	}
+	return append(cleared, a)
+}
+
+// Peak returns the highest source share and region repaints seen by the last
+// sweep.
+func (d *Detector) Peak() (share float64, repaints float64) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	return d.peakShare, d.peakRepaint
+}
+
+// Flagged reports whether a source prefix is currently flagged.
+func (d *Detector) Flagged(prefix string) bool {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	_, ok := d.flagged[Source][prefix]
+	return ok
+}
+
+// Quarantine quarantines a flagged source prefix until its anomaly is cleared
+// by a sweep, but for at most the window.
+func (d *Detector) Quarantine(prefix string, now time.Time) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	if _, ok := d.flagged[Source][prefix]; ok {
+		d.quarantined[prefix] = now.Add(d.cfg.Window)
+	}
+}
+
+// Quarantined reports whether a source prefix is currently quarantined.
+func (d *Detector) Quarantined(prefix string, now time.Time) bool {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	until, ok := d.quarantined[prefix]
+	return ok && now.Before(until)
+}
+
+// Anomalies returns the currently flagged anomalies, strongest first.
+func (d *Detector) Anomalies() []Anomaly {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+	anomalies := make([]Anomaly, 0)
+	for _, flagged := range d.flagged {
+		for _, a := range flagged {
+			anomalies = append(anomalies, a)
+		}
+	}
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Kind != anomalies[j].Kind {
+			return anomalies[i].Kind < anomalies[j].Kind
+		}
+		return anomalies[i].Value > anomalies[j].Value
+	})
+	return anomalies
+}
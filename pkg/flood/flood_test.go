@@ -0,0 +1,182 @@
+package flood
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestSeries(t *testing.T) {
+	s := newSeries(3)
+	s.add(10, 1)
+	s.add(10, 2)
+	s.add(11, 4)
+	s.add(12, 8)
+
+	tests := []struct {
+		epoch int64
+		total int
+	}{
+		{12, 15},
+		{13, 12},
+		{14, 8},
+		{15, 0},
+	}
+	for _, test := range tests {
+		if total := s.total(test.epoch); total != test.total {
+			t.Errorf("Total at epoch %d is %d, expected %d", test.epoch, total, test.total)
+		}
+	}
+
+	// Reusing a bucket of an expired epoch resets its count
+	s.add(13, 16)
+	if total := s.total(13); total != 28 {
+		t.Errorf("Total after reusing a bucket is %d, expected 28", total)
+	}
+}
+
+func TestNewDetectorInvalid(t *testing.T) {
+	for _, cfg := range []Config{
+		{Window: 0, Buckets: 6, RegionSize: 8},
+		{Window: time.Minute, Buckets: 0, RegionSize: 8},
+		{Window: time.Minute, Buckets: 6, RegionSize: 0},
+	} {
+		_, err := NewDetector(10, 10, cfg)
+		if err == nil {
+			t.Errorf("Created a detector with %+v", cfg)
+		}
+	}
+}
+
+func newTestDetector(t *testing.T) *Detector {
+	// A 10x10 canvas in 5x5 regions, flagging sources above 10% of the canvas
+	// and regions repainted more than twice
+	d, err := NewDetector(10, 10, Config{Window: 6 * time.Second, Buckets: 6, SourceShare: 0.1, RegionSize: 5, RegionRepaints: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestObserveAndSweep(t *testing.T) {
+	d := newTestDetector(t)
+	start := time.Unix(1000, 0)
+
+	tests := []struct {
+		at      time.Duration
+		sources map[string]int
+		regions map[int]int
+		flagged []Anomaly
+	}{
+		{0, map[string]int{"a": 10}, map[int]int{0: 25}, nil},
+		{time.Second, map[string]int{"a": 1}, nil, []Anomaly{{Kind: Source, Key: "a", Value: 0.11}}},
+		// Already flagged sources are not reported again
+		{2 * time.Second, map[string]int{"a": 5, "b": 5}, map[int]int{3: 51}, []Anomaly{{Kind: Region, Key: "5,5", Rect: image.Rect(5, 5, 10, 10), Value: 51.0 / 25}}},
+	}
+	for _, test := range tests {
+		flagged := d.Observe(start.Add(test.at), test.sources, test.regions)
+		if len(flagged) != len(test.flagged) {
+			t.Fatalf("Observe at %v flagged %+v, expected %+v", test.at, flagged, test.flagged)
+		}
+		for i := range flagged {
+			if flagged[i] != test.flagged[i] {
+				t.Errorf("Observe at %v flagged %+v, expected %+v", test.at, flagged[i], test.flagged[i])
+			}
+		}
+	}
+	if !d.Flagged("a") || d.Flagged("b") {
+		t.Errorf("Flagged a: %v, b: %v", d.Flagged("a"), d.Flagged("b"))
+	}
+
+	// Still within the window, the anomalies stay flagged
+	cleared := d.Sweep(start.Add(5 * time.Second))
+	if len(cleared) != 0 || len(d.Anomalies()) != 2 {
+		t.Errorf("Sweep within the window cleared %+v and left %+v", cleared, d.Anomalies())
+	}
+	share, repaints := d.Peak()
+	if share != 0.16 || repaints != 51.0/25 {
+		t.Errorf("Peak share %v and repaints %v", share, repaints)
+	}
+
+	// The writes of the first second left the window, a drops to 6%
+	cleared = d.Sweep(start.Add(6 * time.Second))
+	if len(cleared) != 1 || cleared[0].Key != "a" {
+		t.Errorf("Sweep cleared %+v", cleared)
+	}
+	if d.Flagged("a") {
+		t.Errorf("a is still flagged after its rate dropped")
+	}
+
+	// A source is flagged again once it exceeds the threshold
+	flagged := d.Observe(start.Add(6*time.Second), map[string]int{"a": 10}, nil)
+	if len(flagged) != 1 || flagged[0].Key != "a" {
+		t.Errorf("Observe flagged %+v after clearing", flagged)
+	}
+
+	// Everything is forgotten once the window passed without writes
+	cleared = d.Sweep(start.Add(time.Minute))
+	if len(cleared) != 2 || len(d.Anomalies()) != 0 || len(d.sources) != 0 || len(d.regions) != 0 {
+		t.Errorf("Sweep after the window cleared %+v and left %+v", cleared, d.Anomalies())
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	d := newTestDetector(t)
+	start := time.Unix(1000, 0)
+
+	d.Quarantine("a", start)
+	if d.Quarantined("a", start) {
+		t.Errorf("Quarantined a source that is not flagged")
+	}
+
+	d.Observe(start, map[string]int{"a": 20, "b": 20}, nil)
+	d.Quarantine("a", start)
+	d.Quarantine("b", start)
+	if !d.Quarantined("a", start) || !d.Quarantined("b", start) {
+		t.Fatalf("Flagged sources are not quarantined")
+	}
+
+	// Quarantines are lifted when the flood clears
+	d.Observe(start.Add(5*time.Second), map[string]int{"b": 20}, nil)
+	d.Sweep(start.Add(5 * time.Second))
+	if !d.Quarantined("a", start.Add(5*time.Second)) {
+		t.Errorf("Quarantine of a was lifted while it is flagged")
+	}
+	d.Sweep(start.Add(6 * time.Second))
+	if d.Quarantined("a", start.Add(6*time.Second)) {
+		t.Errorf("Quarantine of a was not lifted when its flood cleared")
+	}
+
+	// And last at most the window, even while still flagged
+	if !d.Flagged("b") || d.Quarantined("b", start.Add(6*time.Second)) {
+		t.Errorf("Quarantine of b does not end after the window")
+	}
+	if _, ok := d.quarantined["b"]; ok {
+		t.Errorf("Expired quarantine was not removed")
+	}
+}
+
+func TestResize(t *testing.T) {
+	d := newTestDetector(t)
+	start := time.Unix(1000, 0)
+	d.Observe(start, map[string]int{"a": 20}, map[int]int{3: 100})
+	if len(d.Anomalies()) != 2 {
+		t.Fatalf("Anomalies before resize %+v", d.Anomalies())
+	}
+
+	d.Resize(20, 5)
+	anomalies := d.Anomalies()
+	if len(anomalies) != 1 || anomalies[0].Kind != Source {
+		t.Errorf("Anomalies after resize %+v", anomalies)
+	}
+	index := d.RegionIndexer()
+	if i := index(15, 0); i != 3 {
+		t.Errorf("Region of (15, 0) is %d, expected 3", i)
+	}
+
+	// Regions counted before the resize are outside of the canvas
+	flagged := d.Observe(start, nil, map[int]int{5: 100, 1: 100})
+	if len(flagged) != 1 || flagged[0].Rect != image.Rect(5, 0, 10, 5) {
+		t.Errorf("Observe after resize flagged %+v", flagged)
+	}
+}
@@ -0,0 +1,165 @@
+package frame
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"sync"
+	"time"
+
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+// Encoding selects one of the cached byte representations of a frame.
+type Encoding int
+
+const (
+	JPEG Encoding = iota
+	PNG
+	RGBA
+	RGB24
+	BGRA
+	RGB565
+	YUV420p
+	numEncodings
+)
+
+// Frame is a composed canvas image. Frames are shared between all consumers
+// and must not be modified once published.
+type Frame struct {
+	Number uint64
+	Time   time.Time
+	Image  *image.RGBA
+
+	once    [numEncodings]sync.Once
+	encoded [numEncodings][]byte
+}
+
+func NewFrame(number uint64, now time.Time, img *image.RGBA) *Frame {
+	return &Frame{
+		Number: number,
+		Time:   now,
+		Image:  img,
+	}
+}
+
+// Bytes returns the frame in the given encoding. Each encoding is produced at
+// most once per frame, no matter how many consumers ask for it.
+func (f *Frame) Bytes(enc Encoding) []byte {
+	if enc < 0 || enc >= numEncodings {
+		return nil
+	}
+	f.once[enc].Do(func() {
+		f.encoded[enc] = f.encode(enc)
+	})
+	return f.encoded[enc]
+}
+
+func (f *Frame) encode(enc Encoding) []byte {
+	buf, _ := encodeImage(f.Image, enc, 0)
+	return buf
+}
+
+// encodeImage encodes img, quality only applies to JPEG and 0 selects the
+// default quality.
+func encodeImage(img *image.RGBA, enc Encoding, quality int) ([]byte, error) {
+	switch enc {
+	case JPEG:
+		if quality == 0 {
+			return utils.ImageToBytes(img), nil
+		}
+		buffer := new(bytes.Buffer)
+		err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: quality})
+		return buffer.Bytes(), err
+	case PNG:
+		buffer := new(bytes.Buffer)
+		err := png.Encode(buffer, img)
+		return buffer.Bytes(), err
+	case RGBA:
+		return packedPixels(img, 4), nil
+	case RGB24:
+		return packedPixels(img, 3), nil
+	case BGRA:
+		return bgraPixels(img), nil
+	case RGB565:
+		return rgb565Pixels(img), nil
+	case YUV420p:
+		return yuv420pPixels(img), nil
+	}
+	return nil, fmt.Errorf("%w: unknown encoding %d", ErrInvalidOptions, enc)
+}
+
+// packedPixels copies the first n channels of every pixel without row padding.
+func packedPixels(img *image.RGBA, n int) []byte {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	buf := make([]byte, width*height*n)
+	bufI := 0
+	for y := 0; y < height; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*4]
+		for x := 0; x < width*4; x += 4 {
+			copy(buf[bufI:bufI+n], row[x:x+n])
+			bufI += n
+		}
+	}
+	return buf
+}
+
+func bgraPixels(img *image.RGBA) []byte {
+	buf := packedPixels(img, 4)
+	for i := 0; i < len(buf); i += 4 {
+		buf[i], buf[i+2] = buf[i+2], buf[i]
+	}
+	return buf
+}
+
+// rgb565Pixels packs every pixel into a little endian 5-6-5 bit word.
+func rgb565Pixels(img *image.RGBA) []byte {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	buf := make([]byte, width*height*2)
+	bufI := 0
+	for y := 0; y < height; y++ {
+		row := img.Pix[y*img.Stride : y*img.Stride+width*4]
+		for x := 0; x < width*4; x += 4 {
+			v := uint16(row[x]>>3)<<11 | uint16(row[x+1]>>2)<<5 | uint16(row[x+2]>>3)
+			buf[bufI] = uint8(v)
+			buf[bufI+1] = uint8(v >> 8)
+			bufI += 2
+		}
+	}
+	return buf
+}
+
+// yuv420pPixels converts to planar BT.601 YCbCr with the chroma planes
+// subsampled by averaging 2x2 blocks.
+func yuv420pPixels(img *image.RGBA) []byte {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	cw, ch := (width+1)/2, (height+1)/2
+	buf := make([]byte, width*height+2*cw*ch)
+	yPlane, uPlane, vPlane := buf[:width*height], buf[width*height:width*height+cw*ch], buf[width*height+cw*ch:]
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*img.Stride + x*4
+			yPlane[y*width+x], _, _ = color.RGBToYCbCr(img.Pix[i], img.Pix[i+1], img.Pix[i+2])
+		}
+	}
+
+	for cy := 0; cy < ch; cy++ {
+		for cx := 0; cx < cw; cx++ {
+			r, g, b, n := 0, 0, 0, 0
+			for y := cy * 2; y < cy*2+2 && y < height; y++ {
+				for x := cx * 2; x < cx*2+2 && x < width; x++ {
+					i := y*img.Stride + x*4
+					r, g, b, n = r+int(img.Pix[i]), g+int(img.Pix[i+1]), b+int(img.Pix[i+2]), n+1
+				}
+			}
+			_, u, v := color.RGBToYCbCr(uint8(r/n), uint8(g/n), uint8(b/n))
+			uPlane[cy*cw+cx] = u
+			vPlane[cy*cw+cx] = v
+		}
+	}
+	return buf
+}
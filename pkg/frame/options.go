@@ -0,0 +1,70 @@
+package frame
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+// ErrInvalidOptions is wrapped by every error caused by invalid Options.
+var ErrInvalidOptions = errors.New("invalid render options")
+
+// MaxScale limits how far a frame may be scaled up.
+const MaxScale = 4.0
+
+// Options describe a custom rendition of a frame. The zero value renders the
+// whole frame at its original size with the default JPEG quality.
+type Options struct {
+	Encoding Encoding
+	Quality  int
+	Crop     image.Rectangle
+	Scale    float64
+}
+
+func (o Options) isDefault() bool {
+	return o.Quality == 0 && o.Crop.Empty() && (o.Scale == 0 || o.Scale == 1)
+}
+
+// Render encodes the frame according to opts and returns the encoded image
+// together with its size. Default options are served from the frame cache.
+func (f *Frame) Render(opts Options) ([]byte, image.Point, error) {
+	if opts.Encoding < 0 || opts.Encoding >= numEncodings {
+		return nil, image.Point{}, fmt.Errorf("%w: unknown encoding %d", ErrInvalidOptions, opts.Encoding)
+	}
+	if opts.isDefault() {
+		return f.Bytes(opts.Encoding), f.Image.Rect.Size(), nil
+	}
+	if opts.Quality < 0 || opts.Quality > 100 {
+		return nil, image.Point{}, fmt.Errorf("%w: quality %d outside of 1-100", ErrInvalidOptions, opts.Quality)
+	}
+
+	img := f.Image
+	if !opts.Crop.Empty() {
+		if !opts.Crop.In(f.Image.Rect) {
+			return nil, image.Point{}, fmt.Errorf("%w: crop %v outside of %v", ErrInvalidOptions, opts.Crop, f.Image.Rect)
+		}
+		img = img.SubImage(opts.Crop).(*image.RGBA)
+	}
+
+	if opts.Scale != 0 && opts.Scale != 1 {
+		if opts.Scale < 0 || opts.Scale > MaxScale {
+			return nil, image.Point{}, fmt.Errorf("%w: scale %v outside of 0-%v", ErrInvalidOptions, opts.Scale, MaxScale)
+		}
+		size := img.Rect.Size()
+		width, height := int(float64(size.X)*opts.Scale), int(float64(size.Y)*opts.Scale)
+		if width < 1 || height < 1 {
+			return nil, image.Point{}, fmt.Errorf("%w: scale %v leaves no pixels", ErrInvalidOptions, opts.Scale)
+		}
+		scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.ApproxBiLinear.Scale(scaled, scaled.Rect, img, img.Rect, draw.Src, nil)
+		img = scaled
+	}
+
+	buf, err := encodeImage(img, opts.Encoding, opts.Quality)
+	if err != nil {
+		return nil, image.Point{}, err
+	}
+	return buf, img.Rect.Size(), nil
+}
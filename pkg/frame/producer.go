@@ -0,0 +1,177 @@
+package frame
+
+import (
+	"errors"
+	"image"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrTilesDisabled is returned by ChangedTiles unless EnableTiles was called.
+var ErrTilesDisabled = errors.New("Tile tracking is disabled")
+
+// RenderFunc composes the image for the given point in time.
+type RenderFunc func(now time.Time) (*image.RGBA, error)
+
+// Producer renders one frame per tick and hands the same frame to every
+// consumer, so the rendering cost does not grow with the number of viewers.
+type Producer struct {
+	render   RenderFunc
+	interval time.Duration
+	current  *Frame
+	channels []chan *Frame
+	tiles    *tileHistory
+	frozen   bool
+	lastTick time.Time
+	mut      sync.Mutex
+}
+
+func NewProducer(render RenderFunc, fps int) *Producer {
+	return &Producer{
+		render:   render,
+		interval: time.Second / time.Duration(int64(fps)),
+		channels: make([]chan *Frame, 0),
+	}
+}
+
+// EnableTiles makes the producer checksum every frame in tiles of tileSize
+// pixels and remember the checksums of the last history frames. Tiles stay
+// disabled unless both are positive.
+func (p *Producer) EnableTiles(tileSize int, history int) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if tileSize <= 0 || history <= 0 {
+		p.tiles = nil
+		return
+	}
+	p.tiles = newTileHistory(tileSize, history)
+}
+
+// ChangedTiles returns the current frame and the tiles that changed since the
+// given frame number. All tiles are returned with full set if the changes
+// cannot be determined. The frame is nil if none was rendered yet.
+func (p *Producer) ChangedTiles(since uint64) (f *Frame, tiles []image.Rectangle, full bool, err error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if p.tiles == nil {
+		return nil, nil, false, ErrTilesDisabled
+	}
+	if p.current == nil {
+		return nil, nil, false, nil
+	}
+	tiles, full = p.tiles.changed(p.current, since)
+	return p.current, tiles, full, nil
+}
+
+// SetFps changes the frame rate from the next frame on.
+func (p *Producer) SetFps(fps int) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.interval = time.Second / time.Duration(int64(fps))
+}
+
+// SetFrozen stops or resumes rendering. While frozen the current frame is
+// published again every tick, so consumers keep receiving frames.
+func (p *Producer) SetFrozen(frozen bool) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.frozen = frozen
+}
+
+// Run renders frames until the process exits.
+func (p *Producer) Run() {
+	number := uint64(0)
+	nextTime := time.Now()
+	for {
+		p.mut.Lock()
+		frozen, current, interval := p.frozen, p.current, p.interval
+		p.lastTick = time.Now()
+		p.mut.Unlock()
+
+		if frozen && current != nil {
+			p.publish(current, nil)
+		} else {
+			now := time.Now()
+			img, err := p.render(now)
+			if err != nil {
+				log.Printf("Error rendering frame: %v", err)
+			} else {
+				number++
+				p.publish(NewFrame(number, now, img), p.checksums(img))
+			}
+		}
+
+		nextTime = nextTime.Add(interval)
+		if now := time.Now(); nextTime.Before(now.Add(-interval)) {
+			// Do not try to catch up after stalls or a frame rate change
+			nextTime = now
+		}
+		time.Sleep(time.Until(nextTime))
+	}
+}
+
+// LastTick returns when the render loop last started a frame, it is zero
+// before Run is called.
+func (p *Producer) LastTick() time.Time {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return p.lastTick
+}
+
+// Current returns the most recent frame, or nil before the first one is done.
+func (p *Producer) Current() *Frame {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return p.current
+}
+
+// Subscribe returns a channel receiving every new frame. Slow consumers skip
+// frames instead of holding up the producer.
+func (p *Producer) Subscribe() chan *Frame {
+	c := make(chan *Frame, 1)
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.channels = append(p.channels, c)
+	return c
+}
+
+func (p *Producer) Unsubscribe(c chan *Frame) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	newChannels := make([]chan *Frame, 0)
+	for _, d := range p.channels {
+		if d != c {
+			newChannels = append(newChannels, d)
+		}
+	}
+	p.channels = newChannels
+}
+
+// checksums computes the tile checksums of img if tile tracking is enabled.
+func (p *Producer) checksums(img *image.RGBA) []uint32 {
+	p.mut.Lock()
+	tiles := p.tiles
+	p.mut.Unlock()
+	if tiles == nil {
+		return nil
+	}
+	return tiles.checksums(img)
+}
+
+func (p *Producer) publish(f *Frame, sums []uint32) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.current = f
+	if p.tiles != nil && sums != nil {
+		p.tiles.add(f, sums)
+	}
+	for _, c := range p.channels {
+		// Replace a frame the consumer has not picked up yet
+		select {
+		case <-c:
+		default:
+		}
+		c <- f
+	}
+}
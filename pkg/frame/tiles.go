@@ -0,0 +1,88 @@
+package frame
+
+import (
+	"hash/crc32"
+	"image"
+)
+
+// tileState holds the checksum of every tile of one frame.
+type tileState struct {
+	number uint64
+	sums   []uint32
+	size   image.Point
+}
+
+// tileHistory remembers tile checksums of the most recent frames, so changes
+// can be computed against frames that are no longer kept in memory.
+type tileHistory struct {
+	tileSize int
+	states   []tileState
+	max      int
+}
+
+func newTileHistory(tileSize int, max int) *tileHistory {
+	return &tileHistory{
+		tileSize: tileSize,
+		states:   make([]tileState, 0, max),
+		max:      max,
+	}
+}
+
+// tiles returns the tile rectangles covering an image of the given bounds, in
+// the same order as the checksums.
+func (h *tileHistory) tiles(bounds image.Rectangle) []image.Rectangle {
+	rects := make([]image.Rectangle, 0)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += h.tileSize {
+		for x := bounds.Min.X; x < bounds.Max.X; x += h.tileSize {
+			rects = append(rects, image.Rect(x, y, x+h.tileSize, y+h.tileSize).Intersect(bounds))
+		}
+	}
+	return rects
+}
+
+func (h *tileHistory) checksums(img *image.RGBA) []uint32 {
+	rects := h.tiles(img.Rect)
+	sums := make([]uint32, len(rects))
+	for i, r := range rects {
+		sum := uint32(0)
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			sum = crc32.Update(sum, crc32.IEEETable, img.Pix[img.PixOffset(r.Min.X, y):img.PixOffset(r.Max.X, y)])
+		}
+		sums[i] = sum
+	}
+	return sums
+}
+
+func (h *tileHistory) add(f *Frame, sums []uint32) {
+	if len(h.states) == h.max {
+		h.states = append(h.states[:0], h.states[1:]...)
+	}
+	h.states = append(h.states, tileState{number: f.Number, sums: sums, size: f.Image.Rect.Size()})
+}
+
+func (h *tileHistory) find(number uint64) *tileState {
+	for i := range h.states {
+		if h.states[i].number == number {
+			return &h.states[i]
+		}
+	}
+	return nil
+}
+
+// changed returns the tiles of f that differ from frame since. All tiles are
+// returned with full set when since is unknown or has a different size.
+func (h *tileHistory) changed(f *Frame, since uint64) (tiles []image.Rectangle, full bool) {
+	rects := h.tiles(f.Image.Rect)
+	current, old := h.find(f.Number), h.find(since)
+	if current == nil || old == nil || old.size != current.size {
+		return rects, true
+	}
+
+	tiles = make([]image.Rectangle, 0)
+	for i, r := range rects {
+		if current.sums[i] != old.sums[i] {
+			tiles = append(tiles, r)
+		}
+	}
+	return tiles, false
+}
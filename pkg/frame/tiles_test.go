@@ -0,0 +1,40 @@
+package frame
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestEnableTilesWithoutHistory(t *testing.T) {
+	p := NewProducer(func(now time.Time) (*image.RGBA, error) { return image.NewRGBA(image.Rect(0, 0, 4, 4)), nil }, 25)
+	p.EnableTiles(2, 0)
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	p.publish(NewFrame(1, time.Now(), img), p.checksums(img))
+	if _, _, _, err := p.ChangedTiles(0); err != ErrTilesDisabled {
+		t.Errorf("ChangedTiles returned %v, want ErrTilesDisabled", err)
+	}
+}
+
+func TestChangedTiles(t *testing.T) {
+	h := newTileHistory(2, 2)
+	img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	first := NewFrame(1, time.Now(), img)
+	h.add(first, h.checksums(img))
+
+	changed := image.NewRGBA(img.Rect)
+	changed.Pix[changed.PixOffset(3, 2)] = 255
+	second := NewFrame(2, time.Now(), changed)
+	h.add(second, h.checksums(changed))
+
+	tiles, full := h.changed(second, 1)
+	if full || len(tiles) != 1 || tiles[0] != image.Rect(2, 2, 4, 3) {
+		t.Errorf("Changed tiles are %v (full %v)", tiles, full)
+	}
+
+	third := NewFrame(3, time.Now(), changed)
+	h.add(third, h.checksums(changed))
+	if tiles, full := h.changed(third, 1); !full || len(tiles) != 4 {
+		t.Errorf("Frame 1 should be forgotten, got %v (full %v)", tiles, full)
+	}
+}
@@ -0,0 +1,188 @@
+package history
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+// ErrBeforeHistory is returned for rollbacks to before the retained history.
+var ErrBeforeHistory = errors.New("Timestamp is before the retained history")
+
+type entry struct {
+	timestamp int64
+	source    string
+	delta     *pb.NewDeltaImageRequest
+	size      int64
+}
+
+// History retains the deltas applied within maxAge, on top of a base canvas
+// holding the state before the oldest retained delta, so the canvas can be
+// rebuilt without some of them.
+type History struct {
+	base      *canvaspkg.Canvas
+	baseTime  int64
+	clockTime time.Time
+	entries   []entry
+	bytes     int64
+	maxAge    time.Duration
+	maxSize   int
+	maxBytes  int64
+	mut       sync.Mutex
+}
+
+// New creates a history starting at now with the state of the live canvas. A
+// maxSize or maxBytes of 0 disables that limit.
+func New(live *canvaspkg.Canvas, now time.Time, maxAge time.Duration, maxSize int, maxBytes int64) *History {
+	h := &History{
+		base:     live.Clone(),
+		baseTime: now.UnixNano(),
+		entries:  make([]entry, 0),
+		maxAge:   maxAge,
+		maxSize:  maxSize,
+		maxBytes: maxBytes,
+	}
+	h.base.SetClock(h.clock)
+	return h
+}
+
+func (h *History) clock() time.Time {
+	return h.clockTime
+}
+
+// Apply applies a delta received at received to the live canvas and retains
+// it, holding the history lock so rollbacks never miss a delta.
+func (h *History) Apply(live *canvaspkg.Canvas, received time.Time, source string, req *pb.NewDeltaImageRequest) error {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	err := utils.ApplyDelta(live, req)
+	if err != nil {
+		return err
+	}
+
+	size := int64(proto.Size(req))
+	h.entries = append(h.entries, entry{timestamp: received.UnixNano(), source: source, delta: req, size: size})
+	h.bytes += size
+	h.trim(received)
+	return nil
+}
+
+// trim folds entries that are too old, or exceed the entry or byte limit, into
+// the base canvas.
+func (h *History) trim(now time.Time) {
+	oldest := now.Add(-h.maxAge).UnixNano()
+	n := 0
+	for n < len(h.entries) && (h.entries[n].timestamp < oldest || (h.maxSize > 0 && len(h.entries)-n > h.maxSize) || (h.maxBytes > 0 && h.bytes > h.maxBytes)) {
+		h.applyToBase(h.entries[n])
+		h.bytes -= h.entries[n].size
+		n++
+	}
+	if n > 0 {
+		h.entries = append(h.entries[:0], h.entries[n:]...)
+	}
+}
+
+func (h *History) applyToBase(e entry) {
+	h.clockTime = time.Unix(0, e.timestamp)
+	// Entries were valid when they were applied to the live canvas
+	utils.ApplyDelta(h.base, e.delta)
+	h.baseTime = e.timestamp
+}
+
+// rebuild rebuilds the live canvas from the base and the entries keep accepts,
+// dropping the others from the history.
+func (h *History) rebuild(live *canvaspkg.Canvas, keep func(e entry) bool) (*pb.RollbackResponse, error) {
+	rebuilt := h.base.Clone()
+	clockTime := time.Time{}
+	rebuilt.SetClock(func() time.Time { return clockTime })
+
+	kept := make([]entry, 0, len(h.entries))
+	bytes := int64(0)
+	for _, e := range h.entries {
+		if !keep(e) {
+			continue
+		}
+		clockTime = time.Unix(0, e.timestamp)
+		utils.ApplyDelta(rebuilt, e.delta)
+		kept = append(kept, e)
+		bytes += e.size
+	}
+
+	err := live.CopyPixels(rebuilt)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.RollbackResponse{
+		Dropped:  uint64(len(h.entries) - len(kept)),
+		Retained: uint64(len(kept)),
+		Oldest:   h.baseTime,
+	}
+	h.entries = kept
+	h.bytes = bytes
+	return response, nil
+}
+
+// Rollback restores the live canvas to its state at timestamp.
+func (h *History) Rollback(live *canvaspkg.Canvas, timestamp int64, now time.Time) (*pb.RollbackResponse, error) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	h.trim(now)
+	if timestamp < h.baseTime {
+		return nil, ErrBeforeHistory
+	}
+	return h.rebuild(live, func(e entry) bool {
+		return e.timestamp <= timestamp
+	})
+}
+
+// DropSource removes the deltas of source received between from and to, as
+// matched by utils.SourceMatcher. Deltas already folded into the base canvas
+// can no longer be removed, the oldest timestamp of the response tells where
+// the removal started.
+func (h *History) DropSource(live *canvaspkg.Canvas, source string, from int64, to int64, now time.Time) (*pb.RollbackResponse, error) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	h.trim(now)
+	if from < h.baseTime {
+		from = h.baseTime
+	}
+	matches := utils.SourceMatcher(source)
+	return h.rebuild(live, func(e entry) bool {
+		return e.timestamp < from || e.timestamp > to || !matches(e.source)
+	})
+}
+
+// Clear clears the live canvas and forgets all retained deltas.
+func (h *History) Clear(live *canvaspkg.Canvas, now time.Time) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	live.Clear()
+	h.base.Clear()
+	h.baseTime = now.UnixNano()
+	h.entries = h.entries[:0]
+	h.bytes = 0
+}
+
+// Resize resizes the live canvas and forgets all retained deltas, they no
+// longer fit.
+func (h *History) Resize(live *canvaspkg.Canvas, width int, height int, policy canvaspkg.ResizePolicy, now time.Time) error {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	err := live.Resize(width, height, policy)
+	if err != nil {
+		return err
+	}
+	h.base = live.Clone()
+	h.base.SetClock(h.clock)
+	h.baseTime = now.UnixNano()
+	h.entries = h.entries[:0]
+	h.bytes = 0
+	return nil
+}
@@ -0,0 +1,87 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+func pixelDelta(x uint32) *pb.NewDeltaImageRequest {
+	return &pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: x, Y: 0, Rgb: 0xffffff}}}
+}
+
+func TestHistoryByteLimit(t *testing.T) {
+	live := canvaspkg.NewCanvas(8, 1, 1000000000)
+	size := int64(proto.Size(pixelDelta(1)))
+	h := New(live, time.Now(), time.Hour, 0, 3*size)
+	now := time.Now()
+	for x := uint32(1); x < 8; x++ {
+		err := h.Apply(live, now.Add(time.Duration(x)), "", pixelDelta(x))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.bytes > h.maxBytes {
+			t.Fatalf("History holds %d bytes, limit is %d", h.bytes, h.maxBytes)
+		}
+	}
+	if len(h.entries) != 3 {
+		t.Errorf("History retains %d deltas, want 3", len(h.entries))
+	}
+}
+
+func TestDropSourceBeforeHistory(t *testing.T) {
+	live := canvaspkg.NewCanvas(4, 1, uint64(time.Hour))
+	h := New(live, time.Now(), time.Hour, 0, 0)
+	now := time.Now()
+	h.Apply(live, now, "[2001:db8::1]:1000", pixelDelta(0))
+	h.Apply(live, now.Add(1), "[2001:db8::2]:1000", pixelDelta(1))
+	h.Apply(live, now.Add(2), "[2001:db8::1]:2000", pixelDelta(2))
+
+	response, err := h.DropSource(live, "2001:db8::1", 0, now.Add(time.Second).UnixNano(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.GetDropped() != 2 || response.GetRetained() != 1 {
+		t.Errorf("Dropped %d and retained %d deltas", response.GetDropped(), response.GetRetained())
+	}
+	for x, set := range []bool{false, true, false, false} {
+		info, _ := live.GetPixelInfo(x, 0)
+		if (info.R != 0) != set {
+			t.Errorf("Pixel %d is %+v after dropping", x, info)
+		}
+	}
+}
+
+func TestRollback(t *testing.T) {
+	live := canvaspkg.NewCanvas(4, 1, uint64(time.Hour))
+	now := time.Now()
+	h := New(live, now, time.Hour, 0, 0)
+	for x := uint32(0); x < 3; x++ {
+		err := h.Apply(live, now.Add(time.Duration(x+1)), "", pixelDelta(x))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	response, err := h.Rollback(live, now.Add(1).UnixNano(), now.Add(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.GetDropped() != 2 || response.GetRetained() != 1 {
+		t.Errorf("Dropped %d and retained %d deltas", response.GetDropped(), response.GetRetained())
+	}
+	for x, set := range []bool{true, false, false, false} {
+		info, _ := live.GetPixelInfo(x, 0)
+		if (info.R != 0) != set {
+			t.Errorf("Pixel %d is %+v after the rollback", x, info)
+		}
+	}
+
+	_, err = h.Rollback(live, now.Add(-1).UnixNano(), now.Add(4))
+	if err != ErrBeforeHistory {
+		t.Errorf("Rollback before the history returned %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+package mapping
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"sync"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+// MaxScale bounds how far a receiver canvas can be stretched.
+const MaxScale = 64
+
+// Mapping places the width x height canvas of a receiver at Offset of the
+// shared canvas, stretched by Scale and clipped to Clip. An empty clip means
+// the whole canvas. Peer is the address or CIDR prefix the receiver connects
+// from.
+type Mapping struct {
+	ReceiverID string
+	Width      int
+	Height     int
+	Offset     image.Point
+	Scale      float64
+	Clip       image.Rectangle
+	Peer       string
+	peer       *net.IPNet
+}
+
+// validate checks the mapping and parses its peer.
+func (m *Mapping) validate() error {
+	if m.ReceiverID == "" {
+		return errors.New("Mapping has no receiver id")
+	}
+	if m.Peer == "" {
+		return errors.New("Mapping has no peer")
+	}
+	if _, n, err := net.ParseCIDR(m.Peer); err == nil {
+		m.peer = n
+	} else if ip := net.ParseIP(m.Peer); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		m.peer = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+	} else {
+		return fmt.Errorf("Invalid peer %q, expected an address or a CIDR prefix", m.Peer)
+	}
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("Invalid receiver canvas size %dx%d", m.Width, m.Height)
+	}
+	if m.Scale <= 0 || m.Scale > MaxScale || math.IsNaN(m.Scale) {
+		return fmt.Errorf("Scale must be above 0 and at most %d", MaxScale)
+	}
+	return nil
+}
+
+// Allows reports whether deltas of the receiver may come from sender, a peer
+// address as used for delta sources.
+func (m *Mapping) Allows(sender string) bool {
+	ip := utils.SourceIP(sender)
+	return ip != nil && m.peer != nil && m.peer.Contains(ip)
+}
+
+// target returns the canvas rectangle the receiver pixels from xs up to xe in
+// row y are drawn to. Every pixel covers at least one canvas pixel.
+func (m *Mapping) target(xs int, xe int, y int) image.Rectangle {
+	x0, x1 := int(math.Floor(float64(xs)*m.Scale)), int(math.Floor(float64(xe)*m.Scale))
+	y0, y1 := int(math.Floor(float64(y)*m.Scale)), int(math.Floor(float64(y+1)*m.Scale))
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+	return image.Rect(x0, y0, x1, y1).Add(m.Offset)
+}
+
+// Map transforms a delta request of the receiver into a sparse delta for a
+// width x height canvas. Pixels outside of the clip or canvas are dropped.
+func (m *Mapping) Map(req *pb.NewDeltaImageRequest, width int, height int) (*pb.NewDeltaImageRequest, error) {
+	bounds := image.Rect(0, 0, width, height)
+	if !m.Clip.Empty() {
+		bounds = bounds.Intersect(m.Clip)
+	}
+	mapped := &pb.NewDeltaImageRequest{
+		Sources:    req.GetSources(),
+		ReceiverId: req.GetReceiverId(),
+		Runs:       make([]*pb.DeltaRun, 0),
+	}
+	add := func(r image.Rectangle, rgb uint32, source uint32) {
+		r = r.Intersect(bounds)
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			mapped.Runs = append(mapped.Runs, &pb.DeltaRun{X: uint32(r.Min.X), Y: uint32(y), Length: uint32(r.Dx()), Rgb: rgb, Source: source})
+		}
+	}
+
+	if len(req.GetImage()) > 0 {
+		format := canvaspkg.PixelFormat(req.GetFormat())
+		err := canvaspkg.ValidateDelta(req.GetImage(), format, m.Width, m.Height)
+		if err != nil {
+			return nil, err
+		}
+		bpp := format.BytesPerPixel()
+		for y := 0; y < m.Height; y++ {
+			// Draw rows as runs of equally colored pixels
+			start, startRgb := -1, uint32(0)
+			for x := 0; x <= m.Width; x++ {
+				rgb, set := uint32(0), false
+				if x < m.Width {
+					var r, g, b uint8
+					r, g, b, set = format.DecodePixel(req.GetImage()[(y*m.Width+x)*bpp:])
+					rgb = uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+				}
+				if start >= 0 && (!set || rgb != startRgb) {
+					add(m.target(start, x, y), startRgb, req.GetImageSource())
+					start = -1
+				}
+				if set && start < 0 {
+					start, startRgb = x, rgb
+				}
+			}
+		}
+	}
+
+	for _, p := range req.GetPixels() {
+		if p.GetX() >= uint32(m.Width) || p.GetY() >= uint32(m.Height) {
+			return nil, fmt.Errorf("%w: pixel (%d, %d) outside of %dx%d receiver canvas", canvaspkg.ErrInvalidDelta, p.GetX(), p.GetY(), m.Width, m.Height)
+		}
+		add(m.target(int(p.GetX()), int(p.GetX())+1, int(p.GetY())), p.GetRgb(), p.GetSource())
+	}
+
+	for _, r := range req.GetRuns() {
+		if uint64(r.GetX())+uint64(r.GetLength()) > uint64(m.Width) || r.GetY() >= uint32(m.Height) {
+			return nil, fmt.Errorf("%w: run (%d, %d)+%d outside of %dx%d receiver canvas", canvaspkg.ErrInvalidDelta, r.GetX(), r.GetY(), r.GetLength(), m.Width, m.Height)
+		}
+		if r.GetLength() == 0 {
+			continue
+		}
+		add(m.target(int(r.GetX()), int(r.GetX()+r.GetLength()), int(r.GetY())), r.GetRgb(), r.GetSource())
+	}
+
+	return mapped, nil
+}
+
+// Store holds the receiver mappings. Changes are persisted to a JSON file when
+// a path is set.
+type Store struct {
+	path     string
+	mappings map[string]Mapping
+	mut      sync.RWMutex
+}
+
+// NewStore creates a store, loading the mappings from path if the file exists.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:     path,
+		mappings: make(map[string]Mapping),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	mappings := make([]Mapping, 0)
+	err = json.Unmarshal(data, &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid mapping file %s: %v", path, err)
+	}
+	for _, m := range mappings {
+		err := m.validate()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid mapping in %s: %v", path, err)
+		}
+		s.mappings[m.ReceiverID] = m
+	}
+	return s, nil
+}
+
+// save writes the mappings to the mapping file, must be called with the lock
+// held.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.all(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := s.path + ".tmp"
+	err = ioutil.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// Set adds or replaces the mapping of a receiver. A scale of 0 means 1.
+func (s *Store) Set(m Mapping) error {
+	if m.Scale == 0 {
+		m.Scale = 1
+	}
+	err := m.validate()
+	if err != nil {
+		return err
+	}
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.mappings[m.ReceiverID] = m
+	return s.save()
+}
+
+// Remove deletes the mapping of a receiver and reports whether it existed.
+func (s *Store) Remove(receiverID string) (bool, error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if _, ok := s.mappings[receiverID]; !ok {
+		return false, nil
+	}
+	delete(s.mappings, receiverID)
+	return true, s.save()
+}
+
+// Len returns the number of mapped receivers.
+func (s *Store) Len() int {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return len(s.mappings)
+}
+
+func (s *Store) Get(receiverID string) (Mapping, bool) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	m, ok := s.mappings[receiverID]
+	return m, ok
+}
+
+// All returns the mappings ordered by receiver id.
+func (s *Store) All() []Mapping {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.all()
+}
+
+func (s *Store) all() []Mapping {
+	mappings := make([]Mapping, 0, len(s.mappings))
+	for _, m := range s.mappings {
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].ReceiverID < mappings[j].ReceiverID
+	})
+	return mappings
+}
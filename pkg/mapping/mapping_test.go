@@ -0,0 +1,138 @@
+package mapping
+
+import (
+	"errors"
+	"image"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+func TestMap(t *testing.T) {
+	m := Mapping{ReceiverID: "r", Width: 4, Height: 2, Offset: image.Pt(2, 1), Scale: 2, Peer: "192.0.2.1"}
+	if err := m.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	mapped, err := m.Map(&pb.NewDeltaImageRequest{
+		Pixels:  []*pb.DeltaPixel{{X: 0, Y: 0, Rgb: 0xff0000, Source: 1}},
+		Runs:    []*pb.DeltaRun{{X: 1, Y: 1, Length: 3, Rgb: 0x00ff00}},
+		Sources: []string{"a"},
+	}, 8, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The run is clipped to the right edge of the canvas
+	expected := []*pb.DeltaRun{
+		{X: 2, Y: 1, Length: 2, Rgb: 0xff0000, Source: 1},
+		{X: 2, Y: 2, Length: 2, Rgb: 0xff0000, Source: 1},
+		{X: 4, Y: 3, Length: 4, Rgb: 0x00ff00},
+		{X: 4, Y: 4, Length: 4, Rgb: 0x00ff00},
+	}
+	if !reflect.DeepEqual(mapped.GetRuns(), expected) {
+		t.Errorf("Mapped runs %v, expected %v", mapped.GetRuns(), expected)
+	}
+
+	m.Clip = image.Rect(0, 0, 3, 2)
+	mapped, err = m.Map(&pb.NewDeltaImageRequest{Pixels: []*pb.DeltaPixel{{X: 0, Y: 0}, {X: 1, Y: 1}}}, 8, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []*pb.DeltaRun{{X: 2, Y: 1, Length: 1}}
+	if !reflect.DeepEqual(mapped.GetRuns(), expected) {
+		t.Errorf("Clipped runs %v, expected %v", mapped.GetRuns(), expected)
+	}
+}
+
+func TestMapRejectsOutside(t *testing.T) {
+	m := Mapping{ReceiverID: "r", Width: 4, Height: 2, Scale: 1, Peer: "192.0.2.1"}
+	for _, req := range []*pb.NewDeltaImageRequest{
+		{Pixels: []*pb.DeltaPixel{{X: 4, Y: 0}}},
+		{Runs: []*pb.DeltaRun{{X: 2, Y: 0, Length: 3}}},
+		{Runs: []*pb.DeltaRun{{X: 1, Y: 0, Length: 0xffffffff}}},
+		{Image: make([]byte, 3), Format: pb.PixelFormat_RGB24},
+	} {
+		_, err := m.Map(req, 8, 6)
+		if !errors.Is(err, canvaspkg.ErrInvalidDelta) {
+			t.Errorf("Map(%v) returned %v, expected an invalid delta", req, err)
+		}
+	}
+}
+
+func TestAllows(t *testing.T) {
+	for _, test := range []struct {
+		peer    string
+		sender  string
+		allowed bool
+	}{
+		{"192.0.2.1", "192.0.2.1:1234", true},
+		{"192.0.2.1", "192.0.2.2:1234", false},
+		{"192.0.2.0/24", "192.0.2.200:1234", true},
+		{"192.0.2.0/24", "198.51.100.1:1234", false},
+		{"2001:db8::/64", "[2001:db8::1]:1234", true},
+		{"2001:db8::1", "[2001:db8::2]:1234", false},
+		{"192.0.2.1", "", false},
+	} {
+		m := Mapping{ReceiverID: "r", Width: 1, Height: 1, Scale: 1, Peer: test.peer}
+		if err := m.validate(); err != nil {
+			t.Fatal(err)
+		}
+		if m.Allows(test.sender) != test.allowed {
+			t.Errorf("Mapping for %s allows %q: %v, expected %v", test.peer, test.sender, !test.allowed, test.allowed)
+		}
+	}
+
+	for _, peer := range []string{"", "example.com", "192.0.2.0/33"} {
+		m := Mapping{ReceiverID: "r", Width: 1, Height: 1, Scale: 1, Peer: peer}
+		if m.validate() == nil {
+			t.Errorf("Mapping with peer %q is valid", peer)
+		}
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mapping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mappings.json")
+
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Set(Mapping{ReceiverID: "r", Width: 4, Height: 2, Peer: "192.0.2.0/24"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 1 {
+		t.Errorf("Store has %d mappings, expected 1", s.Len())
+	}
+
+	// The peer survives a reload
+	s, err = NewStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, ok := s.Get("r")
+	if !ok {
+		t.Fatalf("Mapping was not persisted")
+	}
+	if m.Scale != 1 || !m.Allows("192.0.2.7:1") {
+		t.Errorf("Reloaded mapping %+v", m)
+	}
+
+	removed, err := s.Remove("r")
+	if err != nil || !removed {
+		t.Fatalf("Remove returned %v, %v", removed, err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Store has %d mappings after remove", s.Len())
+	}
+}
@@ -0,0 +1,268 @@
+package moderation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"sync"
+
+	utils "github.com/sixelping/sixelping-renderer/pkg/sixelping_utils"
+)
+
+// Mask is a region where pixels are ignored. Without a bitmap the whole
+// rectangle is masked, otherwise only the pixels whose bit is set, row by row
+// with the most significant bit first.
+type Mask struct {
+	ID     uint32
+	Rect   image.Rectangle
+	Bitmap []byte `json:",omitempty"`
+}
+
+func (m *Mask) validate() error {
+	if m.Rect.Empty() {
+		return errors.New("Mask rectangle is empty")
+	}
+	if m.Bitmap != nil && len(m.Bitmap) != (m.Rect.Dx()*m.Rect.Dy()+7)/8 {
+		return fmt.Errorf("Mask bitmap must be %d bytes for %dx%d", (m.Rect.Dx()*m.Rect.Dy()+7)/8, m.Rect.Dx(), m.Rect.Dy())
+	}
+	return nil
+}
+
+func (m *Mask) contains(x, y int) bool {
+	if !(image.Point{x, y}).In(m.Rect) {
+		return false
+	}
+	if m.Bitmap == nil {
+		return true
+	}
+	i := (y-m.Rect.Min.Y)*m.Rect.Dx() + (x - m.Rect.Min.X)
+	return m.Bitmap[i/8]&(0x80>>uint(i%8)) != 0
+}
+
+type state struct {
+	NextID  uint32
+	Masks   []Mask
+	Blocked []string
+}
+
+// Moderator holds region masks and blocked source prefixes. Changes are
+// persisted to a JSON file when a path is set.
+type Moderator struct {
+	path    string
+	state   state
+	blocked []*net.IPNet
+	mut     sync.RWMutex
+}
+
+// New creates a moderator, loading its state from path if the file exists.
+func New(path string) (*Moderator, error) {
+	m := &Moderator{
+		path:  path,
+		state: state{NextID: 1, Masks: make([]Mask, 0), Blocked: make([]string, 0)},
+	}
+	if path == "" {
+		return m, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &m.state)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid moderation file %s: %v", path, err)
+	}
+	for i := range m.state.Masks {
+		err := m.state.Masks[i].validate()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid mask %d in %s: %v", m.state.Masks[i].ID, path, err)
+		}
+	}
+	m.blocked, err = parsePrefixes(m.state.Blocked)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid moderation file %s: %v", path, err)
+	}
+	return m, nil
+}
+
+func parsePrefixes(prefixes []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, len(prefixes))
+	for i, p := range prefixes {
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, err
+		}
+		nets[i] = n
+	}
+	return nets, nil
+}
+
+// save writes the state to the moderation file, must be called with the lock
+// held.
+func (m *Moderator) save() error {
+	if m.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(&m.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := m.path + ".tmp"
+	err = ioutil.WriteFile(tmpPath, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, m.path)
+}
+
+// AddMask adds a mask and returns it with its assigned ID.
+func (m *Moderator) AddMask(mask Mask) (Mask, error) {
+	err := mask.validate()
+	if err != nil {
+		return Mask{}, err
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	mask.ID = m.state.NextID
+	m.state.NextID++
+	m.state.Masks = append(m.state.Masks, mask)
+	return mask, m.save()
+}
+
+// RemoveMask removes a mask and reports whether it existed.
+func (m *Moderator) RemoveMask(id uint32) (bool, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	for i, mask := range m.state.Masks {
+		if mask.ID == id {
+			m.state.Masks = append(m.state.Masks[:i], m.state.Masks[i+1:]...)
+			return true, m.save()
+		}
+	}
+	return false, nil
+}
+
+func (m *Moderator) Masks() []Mask {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return append([]Mask{}, m.state.Masks...)
+}
+
+// Block adds a source prefix in CIDR notation to the blocklist.
+func (m *Moderator) Block(prefix string) error {
+	_, n, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return err
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	for _, b := range m.state.Blocked {
+		if b == n.String() {
+			return nil
+		}
+	}
+	m.state.Blocked = append(m.state.Blocked, n.String())
+	sort.Strings(m.state.Blocked)
+	m.blocked, _ = parsePrefixes(m.state.Blocked)
+	return m.save()
+}
+
+// Unblock removes a source prefix from the blocklist and reports whether it
+// was blocked.
+func (m *Moderator) Unblock(prefix string) (bool, error) {
+	_, n, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return false, err
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	for i, b := range m.state.Blocked {
+		if b == n.String() {
+			m.state.Blocked = append(m.state.Blocked[:i], m.state.Blocked[i+1:]...)
+			m.blocked, _ = parsePrefixes(m.state.Blocked)
+			return true, m.save()
+		}
+	}
+	return false, nil
+}
+
+func (m *Moderator) BlockedPrefixes() []string {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return append([]string{}, m.state.Blocked...)
+}
+
+// Masked reports whether a pixel lies within a mask.
+func (m *Moderator) Masked(x, y int) bool {
+	return m.Snapshot().Masked(x, y)
+}
+
+// Blocked reports whether a source lies within a blocked prefix.
+func (m *Moderator) Blocked(source string) bool {
+	return m.Snapshot().Blocked(source)
+}
+
+// Snapshot returns the current masks and blocklist, which allows checking the
+// pixels of a delta without taking a lock per pixel.
+func (m *Moderator) Snapshot() *Snapshot {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return &Snapshot{
+		masks:   append([]Mask{}, m.state.Masks...),
+		blocked: m.blocked,
+		cache:   make(map[string]bool),
+	}
+}
+
+// Snapshot is a copy of the moderation state. Blocked decisions are cached per
+// source for the lifetime of the snapshot, so it should be short lived, e.g.
+// used for a single delta. It is not safe for concurrent use.
+type Snapshot struct {
+	masks   []Mask
+	blocked []*net.IPNet
+	cache   map[string]bool
+}
+
+// Empty reports whether no pixel can be masked or blocked.
+func (s *Snapshot) Empty() bool {
+	return len(s.masks) == 0 && len(s.blocked) == 0
+}
+
+// Masked reports whether a pixel lies within a mask.
+func (s *Snapshot) Masked(x, y int) bool {
+	for i := range s.masks {
+		if s.masks[i].contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// Blocked reports whether a source lies within a blocked prefix.
+func (s *Snapshot) Blocked(source string) bool {
+	if source == "" || len(s.blocked) == 0 {
+		return false
+	}
+	if blocked, ok := s.cache[source]; ok {
+		return blocked
+	}
+	blocked := false
+	if ip := utils.SourceIP(source); ip != nil {
+		for _, n := range s.blocked {
+			if n.Contains(ip) {
+				blocked = true
+				break
+			}
+		}
+	}
+	s.cache[source] = blocked
+	return blocked
+}
@@ -0,0 +1,58 @@
+package moderation
+
+import (
+	"image"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	m, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Snapshot().Empty() {
+		t.Errorf("Snapshot of a new moderator is not empty")
+	}
+
+	_, err = m.AddMask(Mask{Rect: image.Rect(2, 0, 4, 2), Bitmap: []byte{0x90}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.Block("2001:db8::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := m.Snapshot()
+
+	// Later changes do not affect the snapshot
+	_, err = m.Unblock("2001:db8::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.AddMask(Mask{Rect: image.Rect(0, 0, 1, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		x, y   int
+		masked bool
+	}{{2, 0, true}, {3, 0, false}, {2, 1, false}, {3, 1, true}, {0, 0, false}, {4, 0, false}} {
+		if s.Masked(test.x, test.y) != test.masked {
+			t.Errorf("Pixel (%d, %d) masked is %v", test.x, test.y, !test.masked)
+		}
+	}
+	for _, test := range []struct {
+		source  string
+		blocked bool
+	}{{"[2001:db8::1]:1234", true}, {"2001:db8::ffff", true}, {"2001:db8:0:1::1", false}, {"", false}, {"bufconn", false}} {
+		for i := 0; i < 2; i++ {
+			if s.Blocked(test.source) != test.blocked {
+				t.Errorf("Source %q blocked is %v", test.source, !test.blocked)
+			}
+		}
+	}
+	if m.Blocked("2001:db8::1") {
+		t.Errorf("Unblocked prefix is still blocked")
+	}
+}
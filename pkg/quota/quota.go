@@ -0,0 +1,141 @@
+package quota
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limit allows each source prefix of length Bits to set Pixels pixels per
+// Window. Unused quota accumulates up to Pixels, so a source that used its
+// quota has to cool down before it can set pixels again.
+type Limit struct {
+	Bits   int
+	Pixels int
+	Window time.Duration
+}
+
+// ParseLimit parses a limit for prefixes of length bits in the form
+// pixels/window, e.g. 100/1m.
+func ParseLimit(bits int, spec string) (Limit, error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("Invalid quota %q, expected pixels/window", spec)
+	}
+	pixels, err := strconv.Atoi(parts[0])
+	if err != nil || pixels <= 0 {
+		return Limit{}, fmt.Errorf("Invalid quota pixels %q", parts[0])
+	}
+	window, err := time.ParseDuration(parts[1])
+	if err != nil || window <= 0 {
+		return Limit{}, fmt.Errorf("Invalid quota window %q", parts[1])
+	}
+	return Limit{Bits: bits, Pixels: pixels, Window: window}, nil
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("/%d: %d/%v", l.Bits, l.Pixels, l.Window)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket per source prefix and limit.
+type Limiter struct {
+	limits  []Limit
+	buckets []map[string]*bucket
+	mut     sync.Mutex
+}
+
+func NewLimiter(limits []Limit) *Limiter {
+	l := &Limiter{
+		limits:  limits,
+		buckets: make([]map[string]*bucket, len(limits)),
+	}
+	for i := range l.buckets {
+		l.buckets[i] = make(map[string]*bucket)
+	}
+	return l
+}
+
+func (l *Limiter) Limits() []Limit {
+	return l.limits
+}
+
+// refill returns the bucket of prefix for limit i with the tokens accumulated
+// until now, must be called with the lock held.
+func (l *Limiter) refill(i int, prefix string, now time.Time) *bucket {
+	limit := l.limits[i]
+	b, ok := l.buckets[i][prefix]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Pixels), last: now}
+		l.buckets[i][prefix] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(limit.Pixels) * float64(elapsed) / float64(limit.Window)
+		if b.tokens > float64(limit.Pixels) {
+			b.tokens = float64(limit.Pixels)
+		}
+		b.last = now
+	}
+	return b
+}
+
+// Take uses the quota for one pixel of a source, prefixes holds its prefix for
+// every limit in order. It returns -1 when the pixel is allowed, otherwise the
+// index of the exhausted limit. Quota is only used when all limits allow the
+// pixel.
+func (l *Limiter) Take(now time.Time, prefixes []string) int {
+	_, exhausted := l.TakeN(now, prefixes, 1)
+	return exhausted
+}
+
+// TakeN uses the quota for up to n pixels of a source at once and returns how
+// many are allowed, which is the least any limit allows, and the index of that
+// limit or -1 when all n pixels are allowed.
+func (l *Limiter) TakeN(now time.Time, prefixes []string, n int) (int, int) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	allowed, exhausted := n, -1
+	for i := range l.limits {
+		if tokens := int(l.refill(i, prefixes[i], now).tokens); tokens < allowed {
+			allowed, exhausted = tokens, i
+		}
+	}
+	if allowed < 0 {
+		allowed = 0
+	}
+	for i := range l.limits {
+		l.buckets[i][prefixes[i]].tokens -= float64(allowed)
+	}
+	return allowed, exhausted
+}
+
+// Expire forgets the buckets that are full again, they are recreated full on
+// the next pixel.
+func (l *Limiter) Expire(now time.Time) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	for i := range l.limits {
+		for prefix := range l.buckets[i] {
+			if l.refill(i, prefix, now).tokens >= float64(l.limits[i].Pixels) {
+				delete(l.buckets[i], prefix)
+			}
+		}
+	}
+}
+
+// Tracked returns the number of prefixes with used quota per limit.
+func (l *Limiter) Tracked() []int {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	tracked := make([]int, len(l.limits))
+	for i := range l.limits {
+		tracked[i] = len(l.buckets[i])
+	}
+	return tracked
+}
@@ -0,0 +1,122 @@
+package quota
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit(64, "100/1m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit != (Limit{Bits: 64, Pixels: 100, Window: time.Minute}) {
+		t.Errorf("Parsed %+v", limit)
+	}
+	for _, spec := range []string{"", "100", "100/", "/1m", "0/1m", "-1/1m", "100/0s", "100/-1s", "a/1m", "100/1m/2"} {
+		if _, err := ParseLimit(64, spec); err == nil {
+			t.Errorf("Accepted quota %q", spec)
+		}
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	l := NewLimiter([]Limit{{Bits: 128, Pixels: 10, Window: 10 * time.Second}})
+	now := time.Unix(1000, 0)
+	take := func(n int, prefix string) int {
+		allowed := 0
+		for i := 0; i < n; i++ {
+			if l.Take(now, []string{prefix}) < 0 {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	if allowed := take(15, "a"); allowed != 10 {
+		t.Errorf("Allowed %d of 15 pixels with a full bucket of 10", allowed)
+	}
+	if allowed := take(1, "b"); allowed != 1 {
+		t.Errorf("Another prefix shares the exhausted bucket")
+	}
+
+	// One token refills per second
+	now = now.Add(2500 * time.Millisecond)
+	if allowed := take(5, "a"); allowed != 2 {
+		t.Errorf("Allowed %d pixels after 2.5s, want 2", allowed)
+	}
+
+	// Unused quota accumulates up to the bucket size
+	now = now.Add(time.Hour)
+	if allowed := take(15, "a"); allowed != 10 {
+		t.Errorf("Allowed %d pixels after a long pause, want 10", allowed)
+	}
+}
+
+func TestAllLimitsMustAllow(t *testing.T) {
+	l := NewLimiter([]Limit{
+		{Bits: 128, Pixels: 5, Window: time.Minute},
+		{Bits: 64, Pixels: 8, Window: time.Minute},
+	})
+	now := time.Unix(1000, 0)
+	results := make([]int, 0)
+	for _, host := range []string{"a", "a", "a", "a", "a", "a", "b", "b", "b", "b"} {
+		results = append(results, l.Take(now, []string{host, "net"}))
+	}
+	want := []int{-1, -1, -1, -1, -1, 0, -1, -1, -1, 1}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Fatalf("Take results are %v, want %v", results, want)
+		}
+	}
+	// The rejected pixels used no quota of the other limits
+	if tracked := l.Tracked(); tracked[0] != 2 || tracked[1] != 1 {
+		t.Errorf("Tracked %v", tracked)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	l := NewLimiter([]Limit{{Bits: 128, Pixels: 10, Window: 10 * time.Second}})
+	now := time.Unix(1000, 0)
+	l.Take(now, []string{"a"})
+	for i := 0; i < 10; i++ {
+		l.Take(now, []string{"b"})
+	}
+
+	l.Expire(now.Add(5 * time.Second))
+	if tracked := l.Tracked()[0]; tracked != 1 {
+		t.Errorf("Tracking %d prefixes after 5s, want only the exhausted one", tracked)
+	}
+	l.Expire(now.Add(10 * time.Second))
+	if tracked := l.Tracked()[0]; tracked != 0 {
+		t.Errorf("Tracking %d prefixes after their buckets refilled", tracked)
+	}
+}
+
+func TestTakeN(t *testing.T) {
+	l := NewLimiter([]Limit{
+		{Bits: 128, Pixels: 10, Window: 10 * time.Second},
+		{Bits: 64, Pixels: 15, Window: 10 * time.Second},
+	})
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		host      string
+		n         int
+		allowed   int
+		exhausted int
+	}{
+		{"a", 4, 4, -1},
+		{"a", 10, 6, 0},
+		{"a", 1, 0, 0},
+		{"b", 0, 0, -1},
+		// The /64 shared with a has 5 pixels left
+		{"b", 8, 5, 1},
+	}
+	for _, test := range tests {
+		allowed, exhausted := l.TakeN(now, []string{test.host, "net"}, test.n)
+		if allowed != test.allowed || exhausted != test.exhausted {
+			t.Errorf("TakeN(%q, %d) = %d, %d, want %d, %d", test.host, test.n, allowed, exhausted, test.allowed, test.exhausted)
+		}
+	}
+}
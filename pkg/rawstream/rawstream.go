@@ -0,0 +1,217 @@
+package rawstream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+)
+
+// Every frame on a raw stream starts with a fixed size header:
+//
+//	magic       [4]byte "SXPF"
+//	version     uint8
+//	format      uint8
+//	compression uint8
+//	reserved    uint8
+//	width       uint32
+//	height      uint32
+//	frame       uint64
+//	timestamp   int64, nanoseconds since the unix epoch
+//	length      uint32, payload bytes following the header
+//
+// All integers are big endian. Consumers can re-sync by scanning for the magic.
+const (
+	Magic      = "SXPF"
+	Version    = 1
+	HeaderSize = 36
+)
+
+type Format uint8
+
+const (
+	RGB24 Format = iota
+	RGBA
+	BGRA
+	RGB565
+	YUV420p
+)
+
+var formatNames = map[string]Format{
+	"rgb24":   RGB24,
+	"rgba":    RGBA,
+	"bgra":    BGRA,
+	"rgb565":  RGB565,
+	"yuv420p": YUV420p,
+}
+
+var formatEncodings = map[Format]frame.Encoding{
+	RGB24:   frame.RGB24,
+	RGBA:    frame.RGBA,
+	BGRA:    frame.BGRA,
+	RGB565:  frame.RGB565,
+	YUV420p: frame.YUV420p,
+}
+
+func ParseFormat(name string) (Format, error) {
+	f, ok := formatNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("Unknown raw format %q", name)
+	}
+	return f, nil
+}
+
+type Compression uint8
+
+const (
+	None Compression = iota
+	LZ4
+	Zstd
+)
+
+var compressionNames = map[string]Compression{
+	"none": None,
+	"lz4":  LZ4,
+	"zstd": Zstd,
+}
+
+func ParseCompression(name string) (Compression, error) {
+	c, ok := compressionNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("Unknown raw compression %q", name)
+	}
+	return c, nil
+}
+
+type Header struct {
+	Format      Format
+	Compression Compression
+	Width       uint32
+	Height      uint32
+	Frame       uint64
+	Timestamp   int64
+	Length      uint32
+}
+
+func (h *Header) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, HeaderSize)
+	copy(buf, Magic)
+	buf[4] = Version
+	buf[5] = uint8(h.Format)
+	buf[6] = uint8(h.Compression)
+	binary.BigEndian.PutUint32(buf[8:], h.Width)
+	binary.BigEndian.PutUint32(buf[12:], h.Height)
+	binary.BigEndian.PutUint64(buf[16:], h.Frame)
+	binary.BigEndian.PutUint64(buf[24:], uint64(h.Timestamp))
+	binary.BigEndian.PutUint32(buf[32:], h.Length)
+	return buf, nil
+}
+
+func (h *Header) UnmarshalBinary(buf []byte) error {
+	if len(buf) < HeaderSize || string(buf[:4]) != Magic {
+		return fmt.Errorf("Invalid raw frame header")
+	}
+	if buf[4] != Version {
+		return fmt.Errorf("Unsupported raw frame version %d", buf[4])
+	}
+	h.Format = Format(buf[5])
+	h.Compression = Compression(buf[6])
+	h.Width = binary.BigEndian.Uint32(buf[8:])
+	h.Height = binary.BigEndian.Uint32(buf[12:])
+	h.Frame = binary.BigEndian.Uint64(buf[16:])
+	h.Timestamp = int64(binary.BigEndian.Uint64(buf[24:]))
+	h.Length = binary.BigEndian.Uint32(buf[32:])
+	return nil
+}
+
+// Encoder turns frames into raw stream packets. The last packet is cached, so
+// connections sharing an encoder only pay for encoding a frame once.
+type Encoder struct {
+	format      Format
+	compression Compression
+	headers     bool
+	zstd        *zstd.Encoder
+	lastFrame   *frame.Frame
+	lastPacket  []byte
+	mut         sync.Mutex
+}
+
+// NewEncoder creates an encoder, without headers only the payload is written
+// for compatibility with headerless consumers.
+func NewEncoder(format Format, compression Compression, headers bool) (*Encoder, error) {
+	if _, ok := formatEncodings[format]; !ok {
+		return nil, fmt.Errorf("Unknown raw format %d", format)
+	}
+	e := &Encoder{
+		format:      format,
+		compression: compression,
+		headers:     headers,
+	}
+	switch compression {
+	case None, LZ4:
+	case Zstd:
+		var err error
+		e.zstd, err = zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Unknown raw compression %d", compression)
+	}
+	return e, nil
+}
+
+// Packet returns the header and payload of f.
+func (e *Encoder) Packet(f *frame.Frame) ([]byte, error) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if e.lastFrame == f {
+		return e.lastPacket, nil
+	}
+
+	payload, err := e.compress(f.Bytes(formatEncodings[e.format]))
+	if err != nil {
+		return nil, err
+	}
+
+	packet := payload
+	if e.headers {
+		h := &Header{
+			Format:      e.format,
+			Compression: e.compression,
+			Width:       uint32(f.Image.Rect.Dx()),
+			Height:      uint32(f.Image.Rect.Dy()),
+			Frame:       f.Number,
+			Timestamp:   f.Time.UnixNano(),
+			Length:      uint32(len(payload)),
+		}
+		header, _ := h.MarshalBinary()
+		packet = append(header, payload...)
+	}
+
+	e.lastFrame = f
+	e.lastPacket = packet
+	return packet, nil
+}
+
+func (e *Encoder) compress(data []byte) ([]byte, error) {
+	switch e.compression {
+	case LZ4:
+		buffer := new(bytes.Buffer)
+		w := lz4.NewWriter(buffer)
+		_, err := w.Write(data)
+		if err != nil {
+			return nil, err
+		}
+		err = w.Close()
+		return buffer.Bytes(), err
+	case Zstd:
+		return e.zstd.EncodeAll(data, nil), nil
+	}
+	return data, nil
+}
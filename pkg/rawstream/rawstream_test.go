@@ -0,0 +1,135 @@
+package rawstream
+
+import (
+	"bytes"
+	"image"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+	"github.com/sixelping/sixelping-renderer/pkg/frame"
+)
+
+func TestHeaderRoundTrip(t *testing.T) {
+	h := Header{
+		Format:      YUV420p,
+		Compression: Zstd,
+		Width:       1920,
+		Height:      1080,
+		Frame:       1 << 40,
+		Timestamp:   -42,
+		Length:      123456,
+	}
+	buf, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != HeaderSize || string(buf[:4]) != Magic {
+		t.Fatalf("Invalid header %x", buf)
+	}
+
+	var decoded Header
+	err = decoded.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded != h {
+		t.Errorf("Decoded %+v, want %+v", decoded, h)
+	}
+}
+
+func TestHeaderRejectsInvalid(t *testing.T) {
+	h := Header{Width: 1, Height: 1}
+	buf, _ := h.MarshalBinary()
+	badMagic := append([]byte{}, buf...)
+	badMagic[0] = 'X'
+	badVersion := append([]byte{}, buf...)
+	badVersion[4] = Version + 1
+
+	for name, b := range map[string][]byte{"short": buf[:HeaderSize-1], "magic": badMagic, "version": badVersion} {
+		var decoded Header
+		if err := decoded.UnmarshalBinary(b); err == nil {
+			t.Errorf("Accepted header with invalid %s", name)
+		}
+	}
+}
+
+func testFrame() *frame.Frame {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+	return frame.NewFrame(7, time.Unix(0, 1234), img)
+}
+
+func TestPacketDecode(t *testing.T) {
+	f := testFrame()
+	want := f.Bytes(frame.RGB24)
+	tests := []struct {
+		name        string
+		compression Compression
+		decompress  func([]byte) ([]byte, error)
+	}{
+		{"none", None, func(b []byte) ([]byte, error) { return b, nil }},
+		{"lz4", LZ4, func(b []byte) ([]byte, error) {
+			return ioutil.ReadAll(lz4.NewReader(bytes.NewReader(b)))
+		}},
+		{"zstd", Zstd, func(b []byte) ([]byte, error) {
+			d, err := zstd.NewReader(nil)
+			if err != nil {
+				return nil, err
+			}
+			defer d.Close()
+			return d.DecodeAll(b, nil)
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e, err := NewEncoder(RGB24, test.compression, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			packet, err := e.Packet(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var h Header
+			err = h.UnmarshalBinary(packet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h.Format != RGB24 || h.Compression != test.compression || h.Width != 8 || h.Height != 4 || h.Frame != 7 || h.Timestamp != 1234 {
+				t.Errorf("Unexpected header %+v", h)
+			}
+			if int(h.Length) != len(packet)-HeaderSize {
+				t.Fatalf("Header length %d, payload is %d bytes", h.Length, len(packet)-HeaderSize)
+			}
+
+			payload, err := test.decompress(packet[HeaderSize:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(payload, want) {
+				t.Errorf("Decoded payload differs from the frame")
+			}
+		})
+	}
+}
+
+func TestPacketWithoutHeader(t *testing.T) {
+	f := testFrame()
+	e, err := NewEncoder(RGBA, None, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packet, err := e.Packet(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packet, f.Image.Pix) {
+		t.Errorf("Headerless RGBA packet differs from the frame pixels")
+	}
+}
@@ -25,11 +25,150 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+//Pixel layout of NewDeltaImageRequest.image, formats without alpha treat black as unchanged
+type PixelFormat int32
+
+const (
+	PixelFormat_BGRA   PixelFormat = 0
+	PixelFormat_RGBA   PixelFormat = 1
+	PixelFormat_RGB24  PixelFormat = 2
+	PixelFormat_RGB565 PixelFormat = 3
+)
+
+var PixelFormat_name = map[int32]string{
+	0: "BGRA",
+	1: "RGBA",
+	2: "RGB24",
+	3: "RGB565",
+}
+
+var PixelFormat_value = map[string]int32{
+	"BGRA":   0,
+	"RGBA":   1,
+	"RGB24":  2,
+	"RGB565": 3,
+}
+
+func (x PixelFormat) String() string {
+	return proto.EnumName(PixelFormat_name, int32(x))
+}
+
+func (PixelFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{0}
+}
+
+//With a moderation delay the public canvas shows deltas only after the delay,
+//the moderator canvas shows them as soon as they arrive
+type CanvasView int32
+
+const (
+	CanvasView_PUBLIC    CanvasView = 0
+	CanvasView_MODERATOR CanvasView = 1
+)
+
+var CanvasView_name = map[int32]string{
+	0: "PUBLIC",
+	1: "MODERATOR",
+}
+
+var CanvasView_value = map[string]int32{
+	"PUBLIC":    0,
+	"MODERATOR": 1,
+}
+
+func (x CanvasView) String() string {
+	return proto.EnumName(CanvasView_name, int32(x))
+}
+
+func (CanvasView) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{1}
+}
+
+type ImageEncoding int32
+
+const (
+	ImageEncoding_JPEG        ImageEncoding = 0
+	ImageEncoding_PNG         ImageEncoding = 1
+	ImageEncoding_RAW_RGBA    ImageEncoding = 2
+	ImageEncoding_RAW_RGB     ImageEncoding = 3
+	ImageEncoding_RAW_BGRA    ImageEncoding = 4
+	ImageEncoding_RAW_RGB565  ImageEncoding = 5
+	ImageEncoding_RAW_YUV420P ImageEncoding = 6
+)
+
+var ImageEncoding_name = map[int32]string{
+	0: "JPEG",
+	1: "PNG",
+	2: "RAW_RGBA",
+	3: "RAW_RGB",
+	4: "RAW_BGRA",
+	5: "RAW_RGB565",
+	6: "RAW_YUV420P",
+}
+
+var ImageEncoding_value = map[string]int32{
+	"JPEG":        0,
+	"PNG":         1,
+	"RAW_RGBA":    2,
+	"RAW_RGB":     3,
+	"RAW_BGRA":    4,
+	"RAW_RGB565":  5,
+	"RAW_YUV420P": 6,
+}
+
+func (x ImageEncoding) String() string {
+	return proto.EnumName(ImageEncoding_name, int32(x))
+}
+
+func (ImageEncoding) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{2}
+}
+
+//Crop keeps pixels at their coordinates, pad keeps the content centered and
+//scale stretches it to the new size
+type ResizePolicy int32
+
+const (
+	ResizePolicy_CROP  ResizePolicy = 0
+	ResizePolicy_PAD   ResizePolicy = 1
+	ResizePolicy_SCALE ResizePolicy = 2
+)
+
+var ResizePolicy_name = map[int32]string{
+	0: "CROP",
+	1: "PAD",
+	2: "SCALE",
+}
+
+var ResizePolicy_value = map[string]int32{
+	"CROP":  0,
+	"PAD":   1,
+	"SCALE": 2,
+}
+
+func (x ResizePolicy) String() string {
+	return proto.EnumName(ResizePolicy_name, int32(x))
+}
+
+func (ResizePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{3}
+}
+
+//A full-canvas image and/or a sparse list of changed pixels.
+//Pixels are attributed to sources (e.g. the pinging IPv6 prefix) by an index
+//into sources plus one, 0 leaves a pixel unattributed. Sources are at most
+//65535 bytes long.
 type NewDeltaImageRequest struct {
-	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Image                []byte        `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Pixels               []*DeltaPixel `protobuf:"bytes,2,rep,name=pixels,proto3" json:"pixels,omitempty"`
+	Runs                 []*DeltaRun   `protobuf:"bytes,3,rep,name=runs,proto3" json:"runs,omitempty"`
+	Format               PixelFormat   `protobuf:"varint,4,opt,name=format,proto3,enum=PixelFormat" json:"format,omitempty"`
+	Sources              []string      `protobuf:"bytes,5,rep,name=sources,proto3" json:"sources,omitempty"`
+	ImageSource          uint32        `protobuf:"varint,6,opt,name=image_source,json=imageSource,proto3" json:"image_source,omitempty"`
+	ReceiverId           string        `protobuf:"bytes,7,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *NewDeltaImageRequest) Reset()         { *m = NewDeltaImageRequest{} }
@@ -64,411 +203,3943 @@ func (m *NewDeltaImageRequest) GetImage() []byte {
 	return nil
 }
 
-type RenderedImageResponse struct {
-	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *NewDeltaImageRequest) GetPixels() []*DeltaPixel {
+	if m != nil {
+		return m.Pixels
+	}
+	return nil
 }
 
-func (m *RenderedImageResponse) Reset()         { *m = RenderedImageResponse{} }
-func (m *RenderedImageResponse) String() string { return proto.CompactTextString(m) }
-func (*RenderedImageResponse) ProtoMessage()    {}
-func (*RenderedImageResponse) Descriptor() ([]byte, []int) {
+func (m *NewDeltaImageRequest) GetRuns() []*DeltaRun {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+func (m *NewDeltaImageRequest) GetFormat() PixelFormat {
+	if m != nil {
+		return m.Format
+	}
+	return PixelFormat_BGRA
+}
+
+func (m *NewDeltaImageRequest) GetSources() []string {
+	if m != nil {
+		return m.Sources
+	}
+	return nil
+}
+
+func (m *NewDeltaImageRequest) GetImageSource() uint32 {
+	if m != nil {
+		return m.ImageSource
+	}
+	return 0
+}
+
+func (m *NewDeltaImageRequest) GetReceiverId() string {
+	if m != nil {
+		return m.ReceiverId
+	}
+	return ""
+}
+
+//An applied delta as stored in the delta log, timestamp is in nanoseconds since the unix epoch
+//A record with clear set has no delta, the canvas was cleared at timestamp.
+//A record with resize set has no delta, the canvas was resized at timestamp.
+//A record with rollback or drop_source set has no delta, the canvas was rolled
+//back or the deltas of a source were removed at timestamp. The from of a
+//recorded drop_source is where the removal started and its to is never 0.
+type DeltaRecord struct {
+	Timestamp            int64                    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Source               string                   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Delta                *NewDeltaImageRequest    `protobuf:"bytes,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Clear                bool                     `protobuf:"varint,4,opt,name=clear,proto3" json:"clear,omitempty"`
+	Resize               *ResizeRequest           `protobuf:"bytes,5,opt,name=resize,proto3" json:"resize,omitempty"`
+	Rollback             *RollbackCanvasRequest   `protobuf:"bytes,6,opt,name=rollback,proto3" json:"rollback,omitempty"`
+	DropSource           *DropSourceDeltasRequest `protobuf:"bytes,7,opt,name=drop_source,json=dropSource,proto3" json:"drop_source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *DeltaRecord) Reset()         { *m = DeltaRecord{} }
+func (m *DeltaRecord) String() string { return proto.CompactTextString(m) }
+func (*DeltaRecord) ProtoMessage()    {}
+func (*DeltaRecord) Descriptor() ([]byte, []int) {
 	return fileDescriptor_bc675ceef4b1ed56, []int{1}
 }
 
-func (m *RenderedImageResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RenderedImageResponse.Unmarshal(m, b)
+func (m *DeltaRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaRecord.Unmarshal(m, b)
 }
-func (m *RenderedImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RenderedImageResponse.Marshal(b, m, deterministic)
+func (m *DeltaRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaRecord.Marshal(b, m, deterministic)
 }
-func (m *RenderedImageResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RenderedImageResponse.Merge(m, src)
+func (m *DeltaRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaRecord.Merge(m, src)
 }
-func (m *RenderedImageResponse) XXX_Size() int {
-	return xxx_messageInfo_RenderedImageResponse.Size(m)
+func (m *DeltaRecord) XXX_Size() int {
+	return xxx_messageInfo_DeltaRecord.Size(m)
 }
-func (m *RenderedImageResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RenderedImageResponse.DiscardUnknown(m)
+func (m *DeltaRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RenderedImageResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeltaRecord proto.InternalMessageInfo
 
-func (m *RenderedImageResponse) GetImage() []byte {
+func (m *DeltaRecord) GetTimestamp() int64 {
 	if m != nil {
-		return m.Image
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *DeltaRecord) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *DeltaRecord) GetDelta() *NewDeltaImageRequest {
+	if m != nil {
+		return m.Delta
 	}
 	return nil
 }
 
-type CanvasParametersResponse struct {
-	Width                uint32   `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
-	Height               uint32   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
-	Fps                  uint32   `protobuf:"varint,3,opt,name=fps,proto3" json:"fps,omitempty"`
+func (m *DeltaRecord) GetClear() bool {
+	if m != nil {
+		return m.Clear
+	}
+	return false
+}
+
+func (m *DeltaRecord) GetResize() *ResizeRequest {
+	if m != nil {
+		return m.Resize
+	}
+	return nil
+}
+
+func (m *DeltaRecord) GetRollback() *RollbackCanvasRequest {
+	if m != nil {
+		return m.Rollback
+	}
+	return nil
+}
+
+func (m *DeltaRecord) GetDropSource() *DropSourceDeltasRequest {
+	if m != nil {
+		return m.DropSource
+	}
+	return nil
+}
+
+//Restore the canvas to how it was at timestamp, in nanoseconds since the unix epoch
+type RollbackCanvasRequest struct {
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CanvasParametersResponse) Reset()         { *m = CanvasParametersResponse{} }
-func (m *CanvasParametersResponse) String() string { return proto.CompactTextString(m) }
-func (*CanvasParametersResponse) ProtoMessage()    {}
-func (*CanvasParametersResponse) Descriptor() ([]byte, []int) {
+func (m *RollbackCanvasRequest) Reset()         { *m = RollbackCanvasRequest{} }
+func (m *RollbackCanvasRequest) String() string { return proto.CompactTextString(m) }
+func (*RollbackCanvasRequest) ProtoMessage()    {}
+func (*RollbackCanvasRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_bc675ceef4b1ed56, []int{2}
 }
 
-func (m *CanvasParametersResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CanvasParametersResponse.Unmarshal(m, b)
+func (m *RollbackCanvasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackCanvasRequest.Unmarshal(m, b)
 }
-func (m *CanvasParametersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CanvasParametersResponse.Marshal(b, m, deterministic)
+func (m *RollbackCanvasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackCanvasRequest.Marshal(b, m, deterministic)
 }
-func (m *CanvasParametersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CanvasParametersResponse.Merge(m, src)
+func (m *RollbackCanvasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackCanvasRequest.Merge(m, src)
 }
-func (m *CanvasParametersResponse) XXX_Size() int {
-	return xxx_messageInfo_CanvasParametersResponse.Size(m)
+func (m *RollbackCanvasRequest) XXX_Size() int {
+	return xxx_messageInfo_RollbackCanvasRequest.Size(m)
 }
-func (m *CanvasParametersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CanvasParametersResponse.DiscardUnknown(m)
+func (m *RollbackCanvasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackCanvasRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CanvasParametersResponse proto.InternalMessageInfo
+var xxx_messageInfo_RollbackCanvasRequest proto.InternalMessageInfo
 
-func (m *CanvasParametersResponse) GetWidth() uint32 {
+func (m *RollbackCanvasRequest) GetTimestamp() int64 {
 	if m != nil {
-		return m.Width
+		return m.Timestamp
 	}
 	return 0
 }
 
-func (m *CanvasParametersResponse) GetHeight() uint32 {
+//Remove all deltas of source received between from and to, a to of 0 means now.
+//Source is an address, matching deltas sent from any port, or a prefix in CIDR
+//notation. Deltas older than the retained history can no longer be removed.
+type DropSourceDeltasRequest struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	From                 int64    `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropSourceDeltasRequest) Reset()         { *m = DropSourceDeltasRequest{} }
+func (m *DropSourceDeltasRequest) String() string { return proto.CompactTextString(m) }
+func (*DropSourceDeltasRequest) ProtoMessage()    {}
+func (*DropSourceDeltasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{3}
+}
+
+func (m *DropSourceDeltasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropSourceDeltasRequest.Unmarshal(m, b)
+}
+func (m *DropSourceDeltasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropSourceDeltasRequest.Marshal(b, m, deterministic)
+}
+func (m *DropSourceDeltasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropSourceDeltasRequest.Merge(m, src)
+}
+func (m *DropSourceDeltasRequest) XXX_Size() int {
+	return xxx_messageInfo_DropSourceDeltasRequest.Size(m)
+}
+func (m *DropSourceDeltasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropSourceDeltasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropSourceDeltasRequest proto.InternalMessageInfo
+
+func (m *DropSourceDeltasRequest) GetSource() string {
 	if m != nil {
-		return m.Height
+		return m.Source
+	}
+	return ""
+}
+
+func (m *DropSourceDeltasRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
 	}
 	return 0
 }
 
-func (m *CanvasParametersResponse) GetFps() uint32 {
+func (m *DropSourceDeltasRequest) GetTo() int64 {
 	if m != nil {
-		return m.Fps
+		return m.To
 	}
 	return 0
 }
 
-//Message to send metrics out
-type MetricsDatapoint struct {
-	Ipackets             uint64            `protobuf:"varint,1,opt,name=ipackets,proto3" json:"ipackets,omitempty"`
-	Opackets             uint64            `protobuf:"varint,2,opt,name=opackets,proto3" json:"opackets,omitempty"`
-	Dpackets             uint64            `protobuf:"varint,3,opt,name=dpackets,proto3" json:"dpackets,omitempty"`
-	Ibytes               uint64            `protobuf:"varint,4,opt,name=ibytes,proto3" json:"ibytes,omitempty"`
-	Obytes               uint64            `protobuf:"varint,5,opt,name=obytes,proto3" json:"obytes,omitempty"`
-	Mac                  string            `protobuf:"bytes,6,opt,name=mac,proto3" json:"mac,omitempty"`
-	Ipcounters           map[string]uint64 `protobuf:"bytes,7,rep,name=ipcounters,proto3" json:"ipcounters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+//Oldest is the earliest timestamp the canvas can still be rolled back to
+type RollbackResponse struct {
+	Dropped              uint64   `protobuf:"varint,1,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Retained             uint64   `protobuf:"varint,2,opt,name=retained,proto3" json:"retained,omitempty"`
+	Oldest               int64    `protobuf:"varint,3,opt,name=oldest,proto3" json:"oldest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MetricsDatapoint) Reset()         { *m = MetricsDatapoint{} }
-func (m *MetricsDatapoint) String() string { return proto.CompactTextString(m) }
-func (*MetricsDatapoint) ProtoMessage()    {}
-func (*MetricsDatapoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bc675ceef4b1ed56, []int{3}
+func (m *RollbackResponse) Reset()         { *m = RollbackResponse{} }
+func (m *RollbackResponse) String() string { return proto.CompactTextString(m) }
+func (*RollbackResponse) ProtoMessage()    {}
+func (*RollbackResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{4}
 }
 
-func (m *MetricsDatapoint) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MetricsDatapoint.Unmarshal(m, b)
+func (m *RollbackResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RollbackResponse.Unmarshal(m, b)
 }
-func (m *MetricsDatapoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MetricsDatapoint.Marshal(b, m, deterministic)
+func (m *RollbackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RollbackResponse.Marshal(b, m, deterministic)
 }
-func (m *MetricsDatapoint) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsDatapoint.Merge(m, src)
+func (m *RollbackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RollbackResponse.Merge(m, src)
 }
-func (m *MetricsDatapoint) XXX_Size() int {
-	return xxx_messageInfo_MetricsDatapoint.Size(m)
+func (m *RollbackResponse) XXX_Size() int {
+	return xxx_messageInfo_RollbackResponse.Size(m)
 }
-func (m *MetricsDatapoint) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsDatapoint.DiscardUnknown(m)
+func (m *RollbackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RollbackResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsDatapoint proto.InternalMessageInfo
+var xxx_messageInfo_RollbackResponse proto.InternalMessageInfo
 
-func (m *MetricsDatapoint) GetIpackets() uint64 {
+func (m *RollbackResponse) GetDropped() uint64 {
 	if m != nil {
-		return m.Ipackets
+		return m.Dropped
 	}
 	return 0
 }
 
-func (m *MetricsDatapoint) GetOpackets() uint64 {
+func (m *RollbackResponse) GetRetained() uint64 {
 	if m != nil {
-		return m.Opackets
+		return m.Retained
 	}
 	return 0
 }
 
-func (m *MetricsDatapoint) GetDpackets() uint64 {
+func (m *RollbackResponse) GetOldest() int64 {
 	if m != nil {
-		return m.Dpackets
+		return m.Oldest
 	}
 	return 0
 }
 
-func (m *MetricsDatapoint) GetIbytes() uint64 {
+type PixelInfoRequest struct {
+	X                    uint32   `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    uint32   `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PixelInfoRequest) Reset()         { *m = PixelInfoRequest{} }
+func (m *PixelInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*PixelInfoRequest) ProtoMessage()    {}
+func (*PixelInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{5}
+}
+
+func (m *PixelInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PixelInfoRequest.Unmarshal(m, b)
+}
+func (m *PixelInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PixelInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *PixelInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PixelInfoRequest.Merge(m, src)
+}
+func (m *PixelInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_PixelInfoRequest.Size(m)
+}
+func (m *PixelInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PixelInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PixelInfoRequest proto.InternalMessageInfo
+
+func (m *PixelInfoRequest) GetX() uint32 {
 	if m != nil {
-		return m.Ibytes
+		return m.X
 	}
 	return 0
 }
 
-func (m *MetricsDatapoint) GetObytes() uint64 {
+func (m *PixelInfoRequest) GetY() uint32 {
 	if m != nil {
-		return m.Obytes
+		return m.Y
 	}
 	return 0
 }
 
-func (m *MetricsDatapoint) GetMac() string {
+//Stored color and source of a pixel, last_updated is 0 for pixels never set.
+//Times are in nanoseconds.
+type PixelInfoResponse struct {
+	Rgb                  uint32   `protobuf:"varint,1,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	LastUpdated          int64    `protobuf:"varint,2,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Age                  int64    `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	Source               string   `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PixelInfoResponse) Reset()         { *m = PixelInfoResponse{} }
+func (m *PixelInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*PixelInfoResponse) ProtoMessage()    {}
+func (*PixelInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{6}
+}
+
+func (m *PixelInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PixelInfoResponse.Unmarshal(m, b)
+}
+func (m *PixelInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PixelInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *PixelInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PixelInfoResponse.Merge(m, src)
+}
+func (m *PixelInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_PixelInfoResponse.Size(m)
+}
+func (m *PixelInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PixelInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PixelInfoResponse proto.InternalMessageInfo
+
+func (m *PixelInfoResponse) GetRgb() uint32 {
 	if m != nil {
-		return m.Mac
+		return m.Rgb
 	}
-	return ""
+	return 0
 }
 
-func (m *MetricsDatapoint) GetIpcounters() map[string]uint64 {
+func (m *PixelInfoResponse) GetLastUpdated() int64 {
 	if m != nil {
-		return m.Ipcounters
+		return m.LastUpdated
 	}
-	return nil
+	return 0
 }
 
-func init() {
-	proto.RegisterType((*NewDeltaImageRequest)(nil), "NewDeltaImageRequest")
+func (m *PixelInfoResponse) GetAge() int64 {
+	if m != nil {
+		return m.Age
+	}
+	return 0
+}
+
+func (m *PixelInfoResponse) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+//A single changed pixel, rgb is packed as 0xRRGGBB
+type DeltaPixel struct {
+	X                    uint32   `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    uint32   `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Rgb                  uint32   `protobuf:"varint,3,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	Source               uint32   `protobuf:"varint,4,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeltaPixel) Reset()         { *m = DeltaPixel{} }
+func (m *DeltaPixel) String() string { return proto.CompactTextString(m) }
+func (*DeltaPixel) ProtoMessage()    {}
+func (*DeltaPixel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{7}
+}
+
+func (m *DeltaPixel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaPixel.Unmarshal(m, b)
+}
+func (m *DeltaPixel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaPixel.Marshal(b, m, deterministic)
+}
+func (m *DeltaPixel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaPixel.Merge(m, src)
+}
+func (m *DeltaPixel) XXX_Size() int {
+	return xxx_messageInfo_DeltaPixel.Size(m)
+}
+func (m *DeltaPixel) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaPixel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeltaPixel proto.InternalMessageInfo
+
+func (m *DeltaPixel) GetX() uint32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *DeltaPixel) GetY() uint32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *DeltaPixel) GetRgb() uint32 {
+	if m != nil {
+		return m.Rgb
+	}
+	return 0
+}
+
+func (m *DeltaPixel) GetSource() uint32 {
+	if m != nil {
+		return m.Source
+	}
+	return 0
+}
+
+//A horizontal run of length pixels of the same color starting at x, y
+type DeltaRun struct {
+	X                    uint32   `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    uint32   `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Length               uint32   `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	Rgb                  uint32   `protobuf:"varint,4,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	Source               uint32   `protobuf:"varint,5,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeltaRun) Reset()         { *m = DeltaRun{} }
+func (m *DeltaRun) String() string { return proto.CompactTextString(m) }
+func (*DeltaRun) ProtoMessage()    {}
+func (*DeltaRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{8}
+}
+
+func (m *DeltaRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaRun.Unmarshal(m, b)
+}
+func (m *DeltaRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaRun.Marshal(b, m, deterministic)
+}
+func (m *DeltaRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaRun.Merge(m, src)
+}
+func (m *DeltaRun) XXX_Size() int {
+	return xxx_messageInfo_DeltaRun.Size(m)
+}
+func (m *DeltaRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeltaRun proto.InternalMessageInfo
+
+func (m *DeltaRun) GetX() uint32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *DeltaRun) GetY() uint32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *DeltaRun) GetLength() uint32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *DeltaRun) GetRgb() uint32 {
+	if m != nil {
+		return m.Rgb
+	}
+	return 0
+}
+
+func (m *DeltaRun) GetSource() uint32 {
+	if m != nil {
+		return m.Source
+	}
+	return 0
+}
+
+//Periodic acknowledgement on StreamDeltas, counts are totals for the stream.
+//Receivers should slow down while throttle is set.
+type DeltaAck struct {
+	Applied              uint64   `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	Rejected             uint64   `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Queued               uint32   `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	Throttle             bool     `protobuf:"varint,4,opt,name=throttle,proto3" json:"throttle,omitempty"`
+	LastError            string   `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeltaAck) Reset()         { *m = DeltaAck{} }
+func (m *DeltaAck) String() string { return proto.CompactTextString(m) }
+func (*DeltaAck) ProtoMessage()    {}
+func (*DeltaAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{9}
+}
+
+func (m *DeltaAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeltaAck.Unmarshal(m, b)
+}
+func (m *DeltaAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeltaAck.Marshal(b, m, deterministic)
+}
+func (m *DeltaAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeltaAck.Merge(m, src)
+}
+func (m *DeltaAck) XXX_Size() int {
+	return xxx_messageInfo_DeltaAck.Size(m)
+}
+func (m *DeltaAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeltaAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeltaAck proto.InternalMessageInfo
+
+func (m *DeltaAck) GetApplied() uint64 {
+	if m != nil {
+		return m.Applied
+	}
+	return 0
+}
+
+func (m *DeltaAck) GetRejected() uint64 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
+func (m *DeltaAck) GetQueued() uint32 {
+	if m != nil {
+		return m.Queued
+	}
+	return 0
+}
+
+func (m *DeltaAck) GetThrottle() bool {
+	if m != nil {
+		return m.Throttle
+	}
+	return false
+}
+
+func (m *DeltaAck) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+//Timestamp is in nanoseconds since the unix epoch
+type RenderedImageResponse struct {
+	Image                []byte        `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	FrameNumber          uint64        `protobuf:"varint,2,opt,name=frame_number,json=frameNumber,proto3" json:"frame_number,omitempty"`
+	Timestamp            int64         `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Encoding             ImageEncoding `protobuf:"varint,4,opt,name=encoding,proto3,enum=ImageEncoding" json:"encoding,omitempty"`
+	Width                uint32        `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32        `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RenderedImageResponse) Reset()         { *m = RenderedImageResponse{} }
+func (m *RenderedImageResponse) String() string { return proto.CompactTextString(m) }
+func (*RenderedImageResponse) ProtoMessage()    {}
+func (*RenderedImageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{10}
+}
+
+func (m *RenderedImageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedImageResponse.Unmarshal(m, b)
+}
+func (m *RenderedImageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedImageResponse.Marshal(b, m, deterministic)
+}
+func (m *RenderedImageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedImageResponse.Merge(m, src)
+}
+func (m *RenderedImageResponse) XXX_Size() int {
+	return xxx_messageInfo_RenderedImageResponse.Size(m)
+}
+func (m *RenderedImageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedImageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedImageResponse proto.InternalMessageInfo
+
+func (m *RenderedImageResponse) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *RenderedImageResponse) GetFrameNumber() uint64 {
+	if m != nil {
+		return m.FrameNumber
+	}
+	return 0
+}
+
+func (m *RenderedImageResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RenderedImageResponse) GetEncoding() ImageEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ImageEncoding_JPEG
+}
+
+func (m *RenderedImageResponse) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *RenderedImageResponse) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+//Quality only applies to JPEG, 0 selects the default quality.
+//An unset crop renders the whole canvas, a scale of 0 means 1.
+type RenderedImageRequest struct {
+	Encoding             ImageEncoding `protobuf:"varint,1,opt,name=encoding,proto3,enum=ImageEncoding" json:"encoding,omitempty"`
+	Quality              uint32        `protobuf:"varint,2,opt,name=quality,proto3" json:"quality,omitempty"`
+	Crop                 *Rectangle    `protobuf:"bytes,3,opt,name=crop,proto3" json:"crop,omitempty"`
+	Scale                float32       `protobuf:"fixed32,4,opt,name=scale,proto3" json:"scale,omitempty"`
+	View                 CanvasView    `protobuf:"varint,5,opt,name=view,proto3,enum=CanvasView" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RenderedImageRequest) Reset()         { *m = RenderedImageRequest{} }
+func (m *RenderedImageRequest) String() string { return proto.CompactTextString(m) }
+func (*RenderedImageRequest) ProtoMessage()    {}
+func (*RenderedImageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{11}
+}
+
+func (m *RenderedImageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedImageRequest.Unmarshal(m, b)
+}
+func (m *RenderedImageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedImageRequest.Marshal(b, m, deterministic)
+}
+func (m *RenderedImageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedImageRequest.Merge(m, src)
+}
+func (m *RenderedImageRequest) XXX_Size() int {
+	return xxx_messageInfo_RenderedImageRequest.Size(m)
+}
+func (m *RenderedImageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedImageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedImageRequest proto.InternalMessageInfo
+
+func (m *RenderedImageRequest) GetEncoding() ImageEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ImageEncoding_JPEG
+}
+
+func (m *RenderedImageRequest) GetQuality() uint32 {
+	if m != nil {
+		return m.Quality
+	}
+	return 0
+}
+
+func (m *RenderedImageRequest) GetCrop() *Rectangle {
+	if m != nil {
+		return m.Crop
+	}
+	return nil
+}
+
+func (m *RenderedImageRequest) GetScale() float32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *RenderedImageRequest) GetView() CanvasView {
+	if m != nil {
+		return m.View
+	}
+	return CanvasView_PUBLIC
+}
+
+//A delta waiting for the moderation delay, bounds covers all its pixels
+type PendingDelta struct {
+	Id                   uint64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp            int64      `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	PublishAt            int64      `protobuf:"varint,3,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	Source               string     `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Pixels               uint64     `protobuf:"varint,5,opt,name=pixels,proto3" json:"pixels,omitempty"`
+	Bounds               *Rectangle `protobuf:"bytes,6,opt,name=bounds,proto3" json:"bounds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PendingDelta) Reset()         { *m = PendingDelta{} }
+func (m *PendingDelta) String() string { return proto.CompactTextString(m) }
+func (*PendingDelta) ProtoMessage()    {}
+func (*PendingDelta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{12}
+}
+
+func (m *PendingDelta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDelta.Unmarshal(m, b)
+}
+func (m *PendingDelta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDelta.Marshal(b, m, deterministic)
+}
+func (m *PendingDelta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDelta.Merge(m, src)
+}
+func (m *PendingDelta) XXX_Size() int {
+	return xxx_messageInfo_PendingDelta.Size(m)
+}
+func (m *PendingDelta) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDelta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingDelta proto.InternalMessageInfo
+
+func (m *PendingDelta) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *PendingDelta) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *PendingDelta) GetPublishAt() int64 {
+	if m != nil {
+		return m.PublishAt
+	}
+	return 0
+}
+
+func (m *PendingDelta) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *PendingDelta) GetPixels() uint64 {
+	if m != nil {
+		return m.Pixels
+	}
+	return 0
+}
+
+func (m *PendingDelta) GetBounds() *Rectangle {
+	if m != nil {
+		return m.Bounds
+	}
+	return nil
+}
+
+type PendingDeltas struct {
+	Deltas               []*PendingDelta `protobuf:"bytes,1,rep,name=deltas,proto3" json:"deltas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PendingDeltas) Reset()         { *m = PendingDeltas{} }
+func (m *PendingDeltas) String() string { return proto.CompactTextString(m) }
+func (*PendingDeltas) ProtoMessage()    {}
+func (*PendingDeltas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{13}
+}
+
+func (m *PendingDeltas) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDeltas.Unmarshal(m, b)
+}
+func (m *PendingDeltas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDeltas.Marshal(b, m, deterministic)
+}
+func (m *PendingDeltas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDeltas.Merge(m, src)
+}
+func (m *PendingDeltas) XXX_Size() int {
+	return xxx_messageInfo_PendingDeltas.Size(m)
+}
+func (m *PendingDeltas) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDeltas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingDeltas proto.InternalMessageInfo
+
+func (m *PendingDeltas) GetDeltas() []*PendingDelta {
+	if m != nil {
+		return m.Deltas
+	}
+	return nil
+}
+
+//Discards the listed pending deltas and all pending deltas of source. Source is
+//an address, matching deltas sent from any port, or a prefix in CIDR notation.
+type DiscardDeltasRequest struct {
+	Ids                  []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Source               string   `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscardDeltasRequest) Reset()         { *m = DiscardDeltasRequest{} }
+func (m *DiscardDeltasRequest) String() string { return proto.CompactTextString(m) }
+func (*DiscardDeltasRequest) ProtoMessage()    {}
+func (*DiscardDeltasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{14}
+}
+
+func (m *DiscardDeltasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiscardDeltasRequest.Unmarshal(m, b)
+}
+func (m *DiscardDeltasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiscardDeltasRequest.Marshal(b, m, deterministic)
+}
+func (m *DiscardDeltasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardDeltasRequest.Merge(m, src)
+}
+func (m *DiscardDeltasRequest) XXX_Size() int {
+	return xxx_messageInfo_DiscardDeltasRequest.Size(m)
+}
+func (m *DiscardDeltasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardDeltasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardDeltasRequest proto.InternalMessageInfo
+
+func (m *DiscardDeltasRequest) GetIds() []uint64 {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *DiscardDeltasRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type DiscardDeltasResponse struct {
+	Discarded            uint64   `protobuf:"varint,1,opt,name=discarded,proto3" json:"discarded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscardDeltasResponse) Reset()         { *m = DiscardDeltasResponse{} }
+func (m *DiscardDeltasResponse) String() string { return proto.CompactTextString(m) }
+func (*DiscardDeltasResponse) ProtoMessage()    {}
+func (*DiscardDeltasResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{15}
+}
+
+func (m *DiscardDeltasResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiscardDeltasResponse.Unmarshal(m, b)
+}
+func (m *DiscardDeltasResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiscardDeltasResponse.Marshal(b, m, deterministic)
+}
+func (m *DiscardDeltasResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardDeltasResponse.Merge(m, src)
+}
+func (m *DiscardDeltasResponse) XXX_Size() int {
+	return xxx_messageInfo_DiscardDeltasResponse.Size(m)
+}
+func (m *DiscardDeltasResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardDeltasResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardDeltasResponse proto.InternalMessageInfo
+
+func (m *DiscardDeltasResponse) GetDiscarded() uint64 {
+	if m != nil {
+		return m.Discarded
+	}
+	return 0
+}
+
+type Rectangle struct {
+	X                    uint32   `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    uint32   `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Width                uint32   `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32   `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rectangle) Reset()         { *m = Rectangle{} }
+func (m *Rectangle) String() string { return proto.CompactTextString(m) }
+func (*Rectangle) ProtoMessage()    {}
+func (*Rectangle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{16}
+}
+
+func (m *Rectangle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rectangle.Unmarshal(m, b)
+}
+func (m *Rectangle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rectangle.Marshal(b, m, deterministic)
+}
+func (m *Rectangle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rectangle.Merge(m, src)
+}
+func (m *Rectangle) XXX_Size() int {
+	return xxx_messageInfo_Rectangle.Size(m)
+}
+func (m *Rectangle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rectangle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rectangle proto.InternalMessageInfo
+
+func (m *Rectangle) GetX() uint32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *Rectangle) GetY() uint32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *Rectangle) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *Rectangle) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+//A since_frame of 0 requests all tiles
+type RenderedTilesRequest struct {
+	SinceFrame           uint64        `protobuf:"varint,1,opt,name=since_frame,json=sinceFrame,proto3" json:"since_frame,omitempty"`
+	Encoding             ImageEncoding `protobuf:"varint,2,opt,name=encoding,proto3,enum=ImageEncoding" json:"encoding,omitempty"`
+	View                 CanvasView    `protobuf:"varint,3,opt,name=view,proto3,enum=CanvasView" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RenderedTilesRequest) Reset()         { *m = RenderedTilesRequest{} }
+func (m *RenderedTilesRequest) String() string { return proto.CompactTextString(m) }
+func (*RenderedTilesRequest) ProtoMessage()    {}
+func (*RenderedTilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{17}
+}
+
+func (m *RenderedTilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedTilesRequest.Unmarshal(m, b)
+}
+func (m *RenderedTilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedTilesRequest.Marshal(b, m, deterministic)
+}
+func (m *RenderedTilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedTilesRequest.Merge(m, src)
+}
+func (m *RenderedTilesRequest) XXX_Size() int {
+	return xxx_messageInfo_RenderedTilesRequest.Size(m)
+}
+func (m *RenderedTilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedTilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedTilesRequest proto.InternalMessageInfo
+
+func (m *RenderedTilesRequest) GetSinceFrame() uint64 {
+	if m != nil {
+		return m.SinceFrame
+	}
+	return 0
+}
+
+func (m *RenderedTilesRequest) GetEncoding() ImageEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ImageEncoding_JPEG
+}
+
+func (m *RenderedTilesRequest) GetView() CanvasView {
+	if m != nil {
+		return m.View
+	}
+	return CanvasView_PUBLIC
+}
+
+//Full is set when all tiles are sent because since_frame is no longer known
+type RenderedTilesResponse struct {
+	FrameNumber          uint64          `protobuf:"varint,1,opt,name=frame_number,json=frameNumber,proto3" json:"frame_number,omitempty"`
+	Timestamp            int64           `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Width                uint32          `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32          `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Full                 bool            `protobuf:"varint,5,opt,name=full,proto3" json:"full,omitempty"`
+	Encoding             ImageEncoding   `protobuf:"varint,6,opt,name=encoding,proto3,enum=ImageEncoding" json:"encoding,omitempty"`
+	Tiles                []*RenderedTile `protobuf:"bytes,7,rep,name=tiles,proto3" json:"tiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RenderedTilesResponse) Reset()         { *m = RenderedTilesResponse{} }
+func (m *RenderedTilesResponse) String() string { return proto.CompactTextString(m) }
+func (*RenderedTilesResponse) ProtoMessage()    {}
+func (*RenderedTilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{18}
+}
+
+func (m *RenderedTilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedTilesResponse.Unmarshal(m, b)
+}
+func (m *RenderedTilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedTilesResponse.Marshal(b, m, deterministic)
+}
+func (m *RenderedTilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedTilesResponse.Merge(m, src)
+}
+func (m *RenderedTilesResponse) XXX_Size() int {
+	return xxx_messageInfo_RenderedTilesResponse.Size(m)
+}
+func (m *RenderedTilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedTilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedTilesResponse proto.InternalMessageInfo
+
+func (m *RenderedTilesResponse) GetFrameNumber() uint64 {
+	if m != nil {
+		return m.FrameNumber
+	}
+	return 0
+}
+
+func (m *RenderedTilesResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RenderedTilesResponse) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *RenderedTilesResponse) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *RenderedTilesResponse) GetFull() bool {
+	if m != nil {
+		return m.Full
+	}
+	return false
+}
+
+func (m *RenderedTilesResponse) GetEncoding() ImageEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ImageEncoding_JPEG
+}
+
+func (m *RenderedTilesResponse) GetTiles() []*RenderedTile {
+	if m != nil {
+		return m.Tiles
+	}
+	return nil
+}
+
+type RenderedTile struct {
+	Rect                 *Rectangle `protobuf:"bytes,1,opt,name=rect,proto3" json:"rect,omitempty"`
+	Image                []byte     `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RenderedTile) Reset()         { *m = RenderedTile{} }
+func (m *RenderedTile) String() string { return proto.CompactTextString(m) }
+func (*RenderedTile) ProtoMessage()    {}
+func (*RenderedTile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{19}
+}
+
+func (m *RenderedTile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RenderedTile.Unmarshal(m, b)
+}
+func (m *RenderedTile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RenderedTile.Marshal(b, m, deterministic)
+}
+func (m *RenderedTile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RenderedTile.Merge(m, src)
+}
+func (m *RenderedTile) XXX_Size() int {
+	return xxx_messageInfo_RenderedTile.Size(m)
+}
+func (m *RenderedTile) XXX_DiscardUnknown() {
+	xxx_messageInfo_RenderedTile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RenderedTile proto.InternalMessageInfo
+
+func (m *RenderedTile) GetRect() *Rectangle {
+	if m != nil {
+		return m.Rect
+	}
+	return nil
+}
+
+func (m *RenderedTile) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+//A max_fps of 0 streams every rendered frame
+type StreamRenderedImagesRequest struct {
+	Encoding             ImageEncoding `protobuf:"varint,1,opt,name=encoding,proto3,enum=ImageEncoding" json:"encoding,omitempty"`
+	MaxFps               uint32        `protobuf:"varint,2,opt,name=max_fps,json=maxFps,proto3" json:"max_fps,omitempty"`
+	View                 CanvasView    `protobuf:"varint,3,opt,name=view,proto3,enum=CanvasView" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StreamRenderedImagesRequest) Reset()         { *m = StreamRenderedImagesRequest{} }
+func (m *StreamRenderedImagesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamRenderedImagesRequest) ProtoMessage()    {}
+func (*StreamRenderedImagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{20}
+}
+
+func (m *StreamRenderedImagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamRenderedImagesRequest.Unmarshal(m, b)
+}
+func (m *StreamRenderedImagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamRenderedImagesRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamRenderedImagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamRenderedImagesRequest.Merge(m, src)
+}
+func (m *StreamRenderedImagesRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamRenderedImagesRequest.Size(m)
+}
+func (m *StreamRenderedImagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamRenderedImagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamRenderedImagesRequest proto.InternalMessageInfo
+
+func (m *StreamRenderedImagesRequest) GetEncoding() ImageEncoding {
+	if m != nil {
+		return m.Encoding
+	}
+	return ImageEncoding_JPEG
+}
+
+func (m *StreamRenderedImagesRequest) GetMaxFps() uint32 {
+	if m != nil {
+		return m.MaxFps
+	}
+	return 0
+}
+
+func (m *StreamRenderedImagesRequest) GetView() CanvasView {
+	if m != nil {
+		return m.View
+	}
+	return CanvasView_PUBLIC
+}
+
+//Pixels within a mask are ignored. Without a bitmap the whole rect is masked,
+//otherwise the pixels whose bit is set, row by row with the most significant
+//bit first. The id is assigned by AddMask
+type Mask struct {
+	Id                   uint32     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Rect                 *Rectangle `protobuf:"bytes,2,opt,name=rect,proto3" json:"rect,omitempty"`
+	Bitmap               []byte     `protobuf:"bytes,3,opt,name=bitmap,proto3" json:"bitmap,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Mask) Reset()         { *m = Mask{} }
+func (m *Mask) String() string { return proto.CompactTextString(m) }
+func (*Mask) ProtoMessage()    {}
+func (*Mask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{21}
+}
+
+func (m *Mask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Mask.Unmarshal(m, b)
+}
+func (m *Mask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Mask.Marshal(b, m, deterministic)
+}
+func (m *Mask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mask.Merge(m, src)
+}
+func (m *Mask) XXX_Size() int {
+	return xxx_messageInfo_Mask.Size(m)
+}
+func (m *Mask) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mask proto.InternalMessageInfo
+
+func (m *Mask) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Mask) GetRect() *Rectangle {
+	if m != nil {
+		return m.Rect
+	}
+	return nil
+}
+
+func (m *Mask) GetBitmap() []byte {
+	if m != nil {
+		return m.Bitmap
+	}
+	return nil
+}
+
+type MaskId struct {
+	Id                   uint32   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MaskId) Reset()         { *m = MaskId{} }
+func (m *MaskId) String() string { return proto.CompactTextString(m) }
+func (*MaskId) ProtoMessage()    {}
+func (*MaskId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{22}
+}
+
+func (m *MaskId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaskId.Unmarshal(m, b)
+}
+func (m *MaskId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaskId.Marshal(b, m, deterministic)
+}
+func (m *MaskId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaskId.Merge(m, src)
+}
+func (m *MaskId) XXX_Size() int {
+	return xxx_messageInfo_MaskId.Size(m)
+}
+func (m *MaskId) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaskId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaskId proto.InternalMessageInfo
+
+func (m *MaskId) GetId() uint32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+//A source prefix in CIDR notation, e.g. 2001:db8::/48
+type SourcePrefix struct {
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SourcePrefix) Reset()         { *m = SourcePrefix{} }
+func (m *SourcePrefix) String() string { return proto.CompactTextString(m) }
+func (*SourcePrefix) ProtoMessage()    {}
+func (*SourcePrefix) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{23}
+}
+
+func (m *SourcePrefix) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourcePrefix.Unmarshal(m, b)
+}
+func (m *SourcePrefix) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourcePrefix.Marshal(b, m, deterministic)
+}
+func (m *SourcePrefix) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourcePrefix.Merge(m, src)
+}
+func (m *SourcePrefix) XXX_Size() int {
+	return xxx_messageInfo_SourcePrefix.Size(m)
+}
+func (m *SourcePrefix) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourcePrefix.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SourcePrefix proto.InternalMessageInfo
+
+func (m *SourcePrefix) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+type ModerationState struct {
+	Masks                []*Mask  `protobuf:"bytes,1,rep,name=masks,proto3" json:"masks,omitempty"`
+	Blocked              []string `protobuf:"bytes,2,rep,name=blocked,proto3" json:"blocked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModerationState) Reset()         { *m = ModerationState{} }
+func (m *ModerationState) String() string { return proto.CompactTextString(m) }
+func (*ModerationState) ProtoMessage()    {}
+func (*ModerationState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{24}
+}
+
+func (m *ModerationState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModerationState.Unmarshal(m, b)
+}
+func (m *ModerationState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModerationState.Marshal(b, m, deterministic)
+}
+func (m *ModerationState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModerationState.Merge(m, src)
+}
+func (m *ModerationState) XXX_Size() int {
+	return xxx_messageInfo_ModerationState.Size(m)
+}
+func (m *ModerationState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModerationState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModerationState proto.InternalMessageInfo
+
+func (m *ModerationState) GetMasks() []*Mask {
+	if m != nil {
+		return m.Masks
+	}
+	return nil
+}
+
+func (m *ModerationState) GetBlocked() []string {
+	if m != nil {
+		return m.Blocked
+	}
+	return nil
+}
+
+//Pixel_timeout is in nanoseconds
+type CanvasParametersResponse struct {
+	Width                uint32   `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Fps                  uint32   `protobuf:"varint,3,opt,name=fps,proto3" json:"fps,omitempty"`
+	PixelTimeout         uint64   `protobuf:"varint,4,opt,name=pixel_timeout,json=pixelTimeout,proto3" json:"pixel_timeout,omitempty"`
+	IngestionPaused      bool     `protobuf:"varint,5,opt,name=ingestion_paused,json=ingestionPaused,proto3" json:"ingestion_paused,omitempty"`
+	DisplayFrozen        bool     `protobuf:"varint,6,opt,name=display_frozen,json=displayFrozen,proto3" json:"display_frozen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CanvasParametersResponse) Reset()         { *m = CanvasParametersResponse{} }
+func (m *CanvasParametersResponse) String() string { return proto.CompactTextString(m) }
+func (*CanvasParametersResponse) ProtoMessage()    {}
+func (*CanvasParametersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{25}
+}
+
+func (m *CanvasParametersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CanvasParametersResponse.Unmarshal(m, b)
+}
+func (m *CanvasParametersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CanvasParametersResponse.Marshal(b, m, deterministic)
+}
+func (m *CanvasParametersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanvasParametersResponse.Merge(m, src)
+}
+func (m *CanvasParametersResponse) XXX_Size() int {
+	return xxx_messageInfo_CanvasParametersResponse.Size(m)
+}
+func (m *CanvasParametersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanvasParametersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanvasParametersResponse proto.InternalMessageInfo
+
+func (m *CanvasParametersResponse) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *CanvasParametersResponse) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CanvasParametersResponse) GetFps() uint32 {
+	if m != nil {
+		return m.Fps
+	}
+	return 0
+}
+
+func (m *CanvasParametersResponse) GetPixelTimeout() uint64 {
+	if m != nil {
+		return m.PixelTimeout
+	}
+	return 0
+}
+
+func (m *CanvasParametersResponse) GetIngestionPaused() bool {
+	if m != nil {
+		return m.IngestionPaused
+	}
+	return false
+}
+
+func (m *CanvasParametersResponse) GetDisplayFrozen() bool {
+	if m != nil {
+		return m.DisplayFrozen
+	}
+	return false
+}
+
+//Rgb is packed as 0xRRGGBB
+type FillRegionRequest struct {
+	Rect                 *Rectangle `protobuf:"bytes,1,opt,name=rect,proto3" json:"rect,omitempty"`
+	Rgb                  uint32     `protobuf:"varint,2,opt,name=rgb,proto3" json:"rgb,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FillRegionRequest) Reset()         { *m = FillRegionRequest{} }
+func (m *FillRegionRequest) String() string { return proto.CompactTextString(m) }
+func (*FillRegionRequest) ProtoMessage()    {}
+func (*FillRegionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{26}
+}
+
+func (m *FillRegionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FillRegionRequest.Unmarshal(m, b)
+}
+func (m *FillRegionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FillRegionRequest.Marshal(b, m, deterministic)
+}
+func (m *FillRegionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FillRegionRequest.Merge(m, src)
+}
+func (m *FillRegionRequest) XXX_Size() int {
+	return xxx_messageInfo_FillRegionRequest.Size(m)
+}
+func (m *FillRegionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FillRegionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FillRegionRequest proto.InternalMessageInfo
+
+func (m *FillRegionRequest) GetRect() *Rectangle {
+	if m != nil {
+		return m.Rect
+	}
+	return nil
+}
+
+func (m *FillRegionRequest) GetRgb() uint32 {
+	if m != nil {
+		return m.Rgb
+	}
+	return 0
+}
+
+//Pixel_timeout is in nanoseconds
+type PixelTimeoutRequest struct {
+	PixelTimeout         uint64   `protobuf:"varint,1,opt,name=pixel_timeout,json=pixelTimeout,proto3" json:"pixel_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PixelTimeoutRequest) Reset()         { *m = PixelTimeoutRequest{} }
+func (m *PixelTimeoutRequest) String() string { return proto.CompactTextString(m) }
+func (*PixelTimeoutRequest) ProtoMessage()    {}
+func (*PixelTimeoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{27}
+}
+
+func (m *PixelTimeoutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PixelTimeoutRequest.Unmarshal(m, b)
+}
+func (m *PixelTimeoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PixelTimeoutRequest.Marshal(b, m, deterministic)
+}
+func (m *PixelTimeoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PixelTimeoutRequest.Merge(m, src)
+}
+func (m *PixelTimeoutRequest) XXX_Size() int {
+	return xxx_messageInfo_PixelTimeoutRequest.Size(m)
+}
+func (m *PixelTimeoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PixelTimeoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PixelTimeoutRequest proto.InternalMessageInfo
+
+func (m *PixelTimeoutRequest) GetPixelTimeout() uint64 {
+	if m != nil {
+		return m.PixelTimeout
+	}
+	return 0
+}
+
+type FpsRequest struct {
+	Fps                  uint32   `protobuf:"varint,1,opt,name=fps,proto3" json:"fps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FpsRequest) Reset()         { *m = FpsRequest{} }
+func (m *FpsRequest) String() string { return proto.CompactTextString(m) }
+func (*FpsRequest) ProtoMessage()    {}
+func (*FpsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{28}
+}
+
+func (m *FpsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FpsRequest.Unmarshal(m, b)
+}
+func (m *FpsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FpsRequest.Marshal(b, m, deterministic)
+}
+func (m *FpsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FpsRequest.Merge(m, src)
+}
+func (m *FpsRequest) XXX_Size() int {
+	return xxx_messageInfo_FpsRequest.Size(m)
+}
+func (m *FpsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FpsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FpsRequest proto.InternalMessageInfo
+
+func (m *FpsRequest) GetFps() uint32 {
+	if m != nil {
+		return m.Fps
+	}
+	return 0
+}
+
+//A PNG image with the size of the logo, an empty image removes the logo
+type LogoRequest struct {
+	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogoRequest) Reset()         { *m = LogoRequest{} }
+func (m *LogoRequest) String() string { return proto.CompactTextString(m) }
+func (*LogoRequest) ProtoMessage()    {}
+func (*LogoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{29}
+}
+
+func (m *LogoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogoRequest.Unmarshal(m, b)
+}
+func (m *LogoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogoRequest.Marshal(b, m, deterministic)
+}
+func (m *LogoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogoRequest.Merge(m, src)
+}
+func (m *LogoRequest) XXX_Size() int {
+	return xxx_messageInfo_LogoRequest.Size(m)
+}
+func (m *LogoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogoRequest proto.InternalMessageInfo
+
+func (m *LogoRequest) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+//Deltas are rejected while ingestion is paused
+type PauseRequest struct {
+	Paused               bool     `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PauseRequest) Reset()         { *m = PauseRequest{} }
+func (m *PauseRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseRequest) ProtoMessage()    {}
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{30}
+}
+
+func (m *PauseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseRequest.Unmarshal(m, b)
+}
+func (m *PauseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseRequest.Marshal(b, m, deterministic)
+}
+func (m *PauseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseRequest.Merge(m, src)
+}
+func (m *PauseRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseRequest.Size(m)
+}
+func (m *PauseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseRequest proto.InternalMessageInfo
+
+func (m *PauseRequest) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+//Deltas of a receiver are drawn on a width x height canvas of its own, which is
+//placed at x, y of the canvas, stretched by scale and clipped to clip.
+//A scale of 0 means 1, an unset clip means the whole canvas.
+//Peer is the address or CIDR prefix the receiver connects from and is required,
+//deltas with its receiver_id from elsewhere are rejected. Once any receiver is mapped, deltas
+//without the id of a mapped receiver are rejected as well.
+type ReceiverMapping struct {
+	ReceiverId           string     `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	Width                uint32     `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	X                    int32      `protobuf:"varint,4,opt,name=x,proto3" json:"x,omitempty"`
+	Y                    int32      `protobuf:"varint,5,opt,name=y,proto3" json:"y,omitempty"`
+	Scale                float32    `protobuf:"fixed32,6,opt,name=scale,proto3" json:"scale,omitempty"`
+	Clip                 *Rectangle `protobuf:"bytes,7,opt,name=clip,proto3" json:"clip,omitempty"`
+	Peer                 string     `protobuf:"bytes,8,opt,name=peer,proto3" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ReceiverMapping) Reset()         { *m = ReceiverMapping{} }
+func (m *ReceiverMapping) String() string { return proto.CompactTextString(m) }
+func (*ReceiverMapping) ProtoMessage()    {}
+func (*ReceiverMapping) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{31}
+}
+
+func (m *ReceiverMapping) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverMapping.Unmarshal(m, b)
+}
+func (m *ReceiverMapping) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverMapping.Marshal(b, m, deterministic)
+}
+func (m *ReceiverMapping) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverMapping.Merge(m, src)
+}
+func (m *ReceiverMapping) XXX_Size() int {
+	return xxx_messageInfo_ReceiverMapping.Size(m)
+}
+func (m *ReceiverMapping) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverMapping.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverMapping proto.InternalMessageInfo
+
+func (m *ReceiverMapping) GetReceiverId() string {
+	if m != nil {
+		return m.ReceiverId
+	}
+	return ""
+}
+
+func (m *ReceiverMapping) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ReceiverMapping) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiverMapping) GetX() int32 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *ReceiverMapping) GetY() int32 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+func (m *ReceiverMapping) GetScale() float32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *ReceiverMapping) GetClip() *Rectangle {
+	if m != nil {
+		return m.Clip
+	}
+	return nil
+}
+
+func (m *ReceiverMapping) GetPeer() string {
+	if m != nil {
+		return m.Peer
+	}
+	return ""
+}
+
+type ReceiverId struct {
+	ReceiverId           string   `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiverId) Reset()         { *m = ReceiverId{} }
+func (m *ReceiverId) String() string { return proto.CompactTextString(m) }
+func (*ReceiverId) ProtoMessage()    {}
+func (*ReceiverId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{32}
+}
+
+func (m *ReceiverId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverId.Unmarshal(m, b)
+}
+func (m *ReceiverId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverId.Marshal(b, m, deterministic)
+}
+func (m *ReceiverId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverId.Merge(m, src)
+}
+func (m *ReceiverId) XXX_Size() int {
+	return xxx_messageInfo_ReceiverId.Size(m)
+}
+func (m *ReceiverId) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverId proto.InternalMessageInfo
+
+func (m *ReceiverId) GetReceiverId() string {
+	if m != nil {
+		return m.ReceiverId
+	}
+	return ""
+}
+
+type ReceiverMappings struct {
+	Mappings             []*ReceiverMapping `protobuf:"bytes,1,rep,name=mappings,proto3" json:"mappings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ReceiverMappings) Reset()         { *m = ReceiverMappings{} }
+func (m *ReceiverMappings) String() string { return proto.CompactTextString(m) }
+func (*ReceiverMappings) ProtoMessage()    {}
+func (*ReceiverMappings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{33}
+}
+
+func (m *ReceiverMappings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverMappings.Unmarshal(m, b)
+}
+func (m *ReceiverMappings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverMappings.Marshal(b, m, deterministic)
+}
+func (m *ReceiverMappings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverMappings.Merge(m, src)
+}
+func (m *ReceiverMappings) XXX_Size() int {
+	return xxx_messageInfo_ReceiverMappings.Size(m)
+}
+func (m *ReceiverMappings) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverMappings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverMappings proto.InternalMessageInfo
+
+func (m *ReceiverMappings) GetMappings() []*ReceiverMapping {
+	if m != nil {
+		return m.Mappings
+	}
+	return nil
+}
+
+//Pending moderation deltas are discarded and the delta history is reset on resize
+type ResizeRequest struct {
+	Width                uint32       `protobuf:"varint,1,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32       `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Policy               ResizePolicy `protobuf:"varint,3,opt,name=policy,proto3,enum=ResizePolicy" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ResizeRequest) Reset()         { *m = ResizeRequest{} }
+func (m *ResizeRequest) String() string { return proto.CompactTextString(m) }
+func (*ResizeRequest) ProtoMessage()    {}
+func (*ResizeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{34}
+}
+
+func (m *ResizeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResizeRequest.Unmarshal(m, b)
+}
+func (m *ResizeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResizeRequest.Marshal(b, m, deterministic)
+}
+func (m *ResizeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResizeRequest.Merge(m, src)
+}
+func (m *ResizeRequest) XXX_Size() int {
+	return xxx_messageInfo_ResizeRequest.Size(m)
+}
+func (m *ResizeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResizeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResizeRequest proto.InternalMessageInfo
+
+func (m *ResizeRequest) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ResizeRequest) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ResizeRequest) GetPolicy() ResizePolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return ResizePolicy_CROP
+}
+
+//Outputs repeat the last frame while the display is frozen
+type FreezeRequest struct {
+	Frozen               bool     `protobuf:"varint,1,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FreezeRequest) Reset()         { *m = FreezeRequest{} }
+func (m *FreezeRequest) String() string { return proto.CompactTextString(m) }
+func (*FreezeRequest) ProtoMessage()    {}
+func (*FreezeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{35}
+}
+
+func (m *FreezeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreezeRequest.Unmarshal(m, b)
+}
+func (m *FreezeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreezeRequest.Marshal(b, m, deterministic)
+}
+func (m *FreezeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreezeRequest.Merge(m, src)
+}
+func (m *FreezeRequest) XXX_Size() int {
+	return xxx_messageInfo_FreezeRequest.Size(m)
+}
+func (m *FreezeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreezeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreezeRequest proto.InternalMessageInfo
+
+func (m *FreezeRequest) GetFrozen() bool {
+	if m != nil {
+		return m.Frozen
+	}
+	return false
+}
+
+//Prefix is the IPv6 prefix the receiver answers pings for
+type ReceiverInfo struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Mac                  string   `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Version              string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Prefix               string   `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Capabilities         []string `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiverInfo) Reset()         { *m = ReceiverInfo{} }
+func (m *ReceiverInfo) String() string { return proto.CompactTextString(m) }
+func (*ReceiverInfo) ProtoMessage()    {}
+func (*ReceiverInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{36}
+}
+
+func (m *ReceiverInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverInfo.Unmarshal(m, b)
+}
+func (m *ReceiverInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverInfo.Marshal(b, m, deterministic)
+}
+func (m *ReceiverInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverInfo.Merge(m, src)
+}
+func (m *ReceiverInfo) XXX_Size() int {
+	return xxx_messageInfo_ReceiverInfo.Size(m)
+}
+func (m *ReceiverInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverInfo proto.InternalMessageInfo
+
+func (m *ReceiverInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ReceiverInfo) GetMac() string {
+	if m != nil {
+		return m.Mac
+	}
+	return ""
+}
+
+func (m *ReceiverInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ReceiverInfo) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *ReceiverInfo) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+//Receivers should send a heartbeat every heartbeat_interval nanoseconds.
+//Width and height are the size of the canvas the receiver draws on, which
+//differs from the canvas parameters when the receiver is mapped.
+type RegisterReceiverResponse struct {
+	HeartbeatInterval    int64    `protobuf:"varint,1,opt,name=heartbeat_interval,json=heartbeatInterval,proto3" json:"heartbeat_interval,omitempty"`
+	Width                uint32   `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height               uint32   `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterReceiverResponse) Reset()         { *m = RegisterReceiverResponse{} }
+func (m *RegisterReceiverResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterReceiverResponse) ProtoMessage()    {}
+func (*RegisterReceiverResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{37}
+}
+
+func (m *RegisterReceiverResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterReceiverResponse.Unmarshal(m, b)
+}
+func (m *RegisterReceiverResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterReceiverResponse.Marshal(b, m, deterministic)
+}
+func (m *RegisterReceiverResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterReceiverResponse.Merge(m, src)
+}
+func (m *RegisterReceiverResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterReceiverResponse.Size(m)
+}
+func (m *RegisterReceiverResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterReceiverResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterReceiverResponse proto.InternalMessageInfo
+
+func (m *RegisterReceiverResponse) GetHeartbeatInterval() int64 {
+	if m != nil {
+		return m.HeartbeatInterval
+	}
+	return 0
+}
+
+func (m *RegisterReceiverResponse) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *RegisterReceiverResponse) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+//Heartbeats of unknown receivers fail with NOT_FOUND, they have to register again
+type HeartbeatRequest struct {
+	ReceiverId           string   `protobuf:"bytes,1,opt,name=receiver_id,json=receiverId,proto3" json:"receiver_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeartbeatRequest) Reset()         { *m = HeartbeatRequest{} }
+func (m *HeartbeatRequest) String() string { return proto.CompactTextString(m) }
+func (*HeartbeatRequest) ProtoMessage()    {}
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{38}
+}
+
+func (m *HeartbeatRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeartbeatRequest.Unmarshal(m, b)
+}
+func (m *HeartbeatRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeartbeatRequest.Marshal(b, m, deterministic)
+}
+func (m *HeartbeatRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeartbeatRequest.Merge(m, src)
+}
+func (m *HeartbeatRequest) XXX_Size() int {
+	return xxx_messageInfo_HeartbeatRequest.Size(m)
+}
+func (m *HeartbeatRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeartbeatRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeartbeatRequest proto.InternalMessageInfo
+
+func (m *HeartbeatRequest) GetReceiverId() string {
+	if m != nil {
+		return m.ReceiverId
+	}
+	return ""
+}
+
+//Times are in nanoseconds since the unix epoch, delta_rate is in deltas per second
+type ReceiverStatus struct {
+	Info                 *ReceiverInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Address              string        `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Registered           int64         `protobuf:"varint,3,opt,name=registered,proto3" json:"registered,omitempty"`
+	LastSeen             int64         `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Deltas               uint64        `protobuf:"varint,5,opt,name=deltas,proto3" json:"deltas,omitempty"`
+	DeltaRate            float64       `protobuf:"fixed64,6,opt,name=delta_rate,json=deltaRate,proto3" json:"delta_rate,omitempty"`
+	Stale                bool          `protobuf:"varint,7,opt,name=stale,proto3" json:"stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ReceiverStatus) Reset()         { *m = ReceiverStatus{} }
+func (m *ReceiverStatus) String() string { return proto.CompactTextString(m) }
+func (*ReceiverStatus) ProtoMessage()    {}
+func (*ReceiverStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{39}
+}
+
+func (m *ReceiverStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverStatus.Unmarshal(m, b)
+}
+func (m *ReceiverStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverStatus.Marshal(b, m, deterministic)
+}
+func (m *ReceiverStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverStatus.Merge(m, src)
+}
+func (m *ReceiverStatus) XXX_Size() int {
+	return xxx_messageInfo_ReceiverStatus.Size(m)
+}
+func (m *ReceiverStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverStatus proto.InternalMessageInfo
+
+func (m *ReceiverStatus) GetInfo() *ReceiverInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+func (m *ReceiverStatus) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ReceiverStatus) GetRegistered() int64 {
+	if m != nil {
+		return m.Registered
+	}
+	return 0
+}
+
+func (m *ReceiverStatus) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *ReceiverStatus) GetDeltas() uint64 {
+	if m != nil {
+		return m.Deltas
+	}
+	return 0
+}
+
+func (m *ReceiverStatus) GetDeltaRate() float64 {
+	if m != nil {
+		return m.DeltaRate
+	}
+	return 0
+}
+
+func (m *ReceiverStatus) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+type ReceiverList struct {
+	Receivers            []*ReceiverStatus `protobuf:"bytes,1,rep,name=receivers,proto3" json:"receivers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReceiverList) Reset()         { *m = ReceiverList{} }
+func (m *ReceiverList) String() string { return proto.CompactTextString(m) }
+func (*ReceiverList) ProtoMessage()    {}
+func (*ReceiverList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{40}
+}
+
+func (m *ReceiverList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiverList.Unmarshal(m, b)
+}
+func (m *ReceiverList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiverList.Marshal(b, m, deterministic)
+}
+func (m *ReceiverList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiverList.Merge(m, src)
+}
+func (m *ReceiverList) XXX_Size() int {
+	return xxx_messageInfo_ReceiverList.Size(m)
+}
+func (m *ReceiverList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiverList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiverList proto.InternalMessageInfo
+
+func (m *ReceiverList) GetReceivers() []*ReceiverStatus {
+	if m != nil {
+		return m.Receivers
+	}
+	return nil
+}
+
+//Message to send metrics out
+type MetricsDatapoint struct {
+	Ipackets             uint64            `protobuf:"varint,1,opt,name=ipackets,proto3" json:"ipackets,omitempty"`
+	Opackets             uint64            `protobuf:"varint,2,opt,name=opackets,proto3" json:"opackets,omitempty"`
+	Dpackets             uint64            `protobuf:"varint,3,opt,name=dpackets,proto3" json:"dpackets,omitempty"`
+	Ibytes               uint64            `protobuf:"varint,4,opt,name=ibytes,proto3" json:"ibytes,omitempty"`
+	Obytes               uint64            `protobuf:"varint,5,opt,name=obytes,proto3" json:"obytes,omitempty"`
+	Mac                  string            `protobuf:"bytes,6,opt,name=mac,proto3" json:"mac,omitempty"`
+	Ipcounters           map[string]uint64 `protobuf:"bytes,7,rep,name=ipcounters,proto3" json:"ipcounters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MetricsDatapoint) Reset()         { *m = MetricsDatapoint{} }
+func (m *MetricsDatapoint) String() string { return proto.CompactTextString(m) }
+func (*MetricsDatapoint) ProtoMessage()    {}
+func (*MetricsDatapoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc675ceef4b1ed56, []int{41}
+}
+
+func (m *MetricsDatapoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricsDatapoint.Unmarshal(m, b)
+}
+func (m *MetricsDatapoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricsDatapoint.Marshal(b, m, deterministic)
+}
+func (m *MetricsDatapoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsDatapoint.Merge(m, src)
+}
+func (m *MetricsDatapoint) XXX_Size() int {
+	return xxx_messageInfo_MetricsDatapoint.Size(m)
+}
+func (m *MetricsDatapoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsDatapoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsDatapoint proto.InternalMessageInfo
+
+func (m *MetricsDatapoint) GetIpackets() uint64 {
+	if m != nil {
+		return m.Ipackets
+	}
+	return 0
+}
+
+func (m *MetricsDatapoint) GetOpackets() uint64 {
+	if m != nil {
+		return m.Opackets
+	}
+	return 0
+}
+
+func (m *MetricsDatapoint) GetDpackets() uint64 {
+	if m != nil {
+		return m.Dpackets
+	}
+	return 0
+}
+
+func (m *MetricsDatapoint) GetIbytes() uint64 {
+	if m != nil {
+		return m.Ibytes
+	}
+	return 0
+}
+
+func (m *MetricsDatapoint) GetObytes() uint64 {
+	if m != nil {
+		return m.Obytes
+	}
+	return 0
+}
+
+func (m *MetricsDatapoint) GetMac() string {
+	if m != nil {
+		return m.Mac
+	}
+	return ""
+}
+
+func (m *MetricsDatapoint) GetIpcounters() map[string]uint64 {
+	if m != nil {
+		return m.Ipcounters
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("PixelFormat", PixelFormat_name, PixelFormat_value)
+	proto.RegisterEnum("CanvasView", CanvasView_name, CanvasView_value)
+	proto.RegisterEnum("ImageEncoding", ImageEncoding_name, ImageEncoding_value)
+	proto.RegisterEnum("ResizePolicy", ResizePolicy_name, ResizePolicy_value)
+	proto.RegisterType((*NewDeltaImageRequest)(nil), "NewDeltaImageRequest")
+	proto.RegisterType((*DeltaRecord)(nil), "DeltaRecord")
+	proto.RegisterType((*RollbackCanvasRequest)(nil), "RollbackCanvasRequest")
+	proto.RegisterType((*DropSourceDeltasRequest)(nil), "DropSourceDeltasRequest")
+	proto.RegisterType((*RollbackResponse)(nil), "RollbackResponse")
+	proto.RegisterType((*PixelInfoRequest)(nil), "PixelInfoRequest")
+	proto.RegisterType((*PixelInfoResponse)(nil), "PixelInfoResponse")
+	proto.RegisterType((*DeltaPixel)(nil), "DeltaPixel")
+	proto.RegisterType((*DeltaRun)(nil), "DeltaRun")
+	proto.RegisterType((*DeltaAck)(nil), "DeltaAck")
 	proto.RegisterType((*RenderedImageResponse)(nil), "RenderedImageResponse")
+	proto.RegisterType((*RenderedImageRequest)(nil), "RenderedImageRequest")
+	proto.RegisterType((*PendingDelta)(nil), "PendingDelta")
+	proto.RegisterType((*PendingDeltas)(nil), "PendingDeltas")
+	proto.RegisterType((*DiscardDeltasRequest)(nil), "DiscardDeltasRequest")
+	proto.RegisterType((*DiscardDeltasResponse)(nil), "DiscardDeltasResponse")
+	proto.RegisterType((*Rectangle)(nil), "Rectangle")
+	proto.RegisterType((*RenderedTilesRequest)(nil), "RenderedTilesRequest")
+	proto.RegisterType((*RenderedTilesResponse)(nil), "RenderedTilesResponse")
+	proto.RegisterType((*RenderedTile)(nil), "RenderedTile")
+	proto.RegisterType((*StreamRenderedImagesRequest)(nil), "StreamRenderedImagesRequest")
+	proto.RegisterType((*Mask)(nil), "Mask")
+	proto.RegisterType((*MaskId)(nil), "MaskId")
+	proto.RegisterType((*SourcePrefix)(nil), "SourcePrefix")
+	proto.RegisterType((*ModerationState)(nil), "ModerationState")
 	proto.RegisterType((*CanvasParametersResponse)(nil), "CanvasParametersResponse")
+	proto.RegisterType((*FillRegionRequest)(nil), "FillRegionRequest")
+	proto.RegisterType((*PixelTimeoutRequest)(nil), "PixelTimeoutRequest")
+	proto.RegisterType((*FpsRequest)(nil), "FpsRequest")
+	proto.RegisterType((*LogoRequest)(nil), "LogoRequest")
+	proto.RegisterType((*PauseRequest)(nil), "PauseRequest")
+	proto.RegisterType((*ReceiverMapping)(nil), "ReceiverMapping")
+	proto.RegisterType((*ReceiverId)(nil), "ReceiverId")
+	proto.RegisterType((*ReceiverMappings)(nil), "ReceiverMappings")
+	proto.RegisterType((*ResizeRequest)(nil), "ResizeRequest")
+	proto.RegisterType((*FreezeRequest)(nil), "FreezeRequest")
+	proto.RegisterType((*ReceiverInfo)(nil), "ReceiverInfo")
+	proto.RegisterType((*RegisterReceiverResponse)(nil), "RegisterReceiverResponse")
+	proto.RegisterType((*HeartbeatRequest)(nil), "HeartbeatRequest")
+	proto.RegisterType((*ReceiverStatus)(nil), "ReceiverStatus")
+	proto.RegisterType((*ReceiverList)(nil), "ReceiverList")
 	proto.RegisterType((*MetricsDatapoint)(nil), "MetricsDatapoint")
 	proto.RegisterMapType((map[string]uint64)(nil), "MetricsDatapoint.IpcountersEntry")
 }
 
-func init() { proto.RegisterFile("sixelping-command.proto", fileDescriptor_bc675ceef4b1ed56) }
+func init() { proto.RegisterFile("sixelping-command.proto", fileDescriptor_bc675ceef4b1ed56) }
+
+var fileDescriptor_bc675ceef4b1ed56 = []byte{
+	// 2511 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4b, 0x73, 0xdb, 0xc8,
+	0xf1, 0x27, 0x08, 0x3e, 0x9b, 0x0f, 0x41, 0xb3, 0x92, 0x96, 0xa6, 0x5f, 0x32, 0xfc, 0xf7, 0xae,
+	0xfe, 0xce, 0x1a, 0x76, 0xb4, 0x6b, 0xc7, 0xeb, 0xc4, 0x1b, 0x53, 0xcf, 0xd5, 0x96, 0x1f, 0xac,
+	0x91, 0xb5, 0x5b, 0x39, 0x31, 0x20, 0x30, 0xa2, 0x10, 0x81, 0x00, 0x0c, 0x0c, 0x65, 0xc9, 0xd7,
+	0xa4, 0xf2, 0x05, 0x72, 0xce, 0x07, 0x48, 0x2e, 0x49, 0x55, 0xbe, 0x43, 0x2a, 0xd7, 0x9c, 0xf2,
+	0x21, 0x72, 0xcd, 0x07, 0x48, 0xcd, 0x03, 0x20, 0x00, 0x91, 0x54, 0x79, 0x2b, 0x27, 0xa2, 0xbb,
+	0x39, 0x3d, 0xdd, 0x3d, 0xbf, 0xee, 0xe9, 0x1e, 0xf8, 0x34, 0x72, 0xce, 0x89, 0x1b, 0x38, 0xde,
+	0xe8, 0x81, 0xe5, 0x8f, 0xc7, 0xa6, 0x67, 0x1b, 0x41, 0xe8, 0x53, 0xbf, 0x7b, 0x7d, 0xe4, 0xfb,
+	0x23, 0x97, 0x3c, 0xe4, 0xd4, 0x70, 0x72, 0xfc, 0x90, 0x8c, 0x03, 0x7a, 0x21, 0x84, 0xfa, 0x7f,
+	0x14, 0x58, 0x79, 0x4d, 0xde, 0xef, 0x10, 0x97, 0x9a, 0x07, 0x63, 0x73, 0x44, 0x30, 0x79, 0x37,
+	0x21, 0x11, 0x45, 0x2b, 0x50, 0x76, 0x18, 0xdd, 0x51, 0xd6, 0x95, 0x8d, 0x26, 0x16, 0x04, 0xba,
+	0x0b, 0x95, 0x80, 0x6d, 0x13, 0x75, 0x8a, 0xeb, 0xea, 0x46, 0x63, 0xb3, 0x61, 0xf0, 0x95, 0x7d,
+	0xc6, 0xc3, 0x52, 0x84, 0x6e, 0x42, 0x29, 0x9c, 0x78, 0x51, 0x47, 0xe5, 0x7f, 0xa9, 0x8b, 0xbf,
+	0xe0, 0x89, 0x87, 0x39, 0x1b, 0xfd, 0x1f, 0x54, 0x8e, 0xfd, 0x70, 0x6c, 0xd2, 0x4e, 0x69, 0x5d,
+	0xd9, 0x68, 0x6f, 0x36, 0x0d, 0xbe, 0x7c, 0x8f, 0xf3, 0xb0, 0x94, 0xa1, 0x0e, 0x54, 0x23, 0x7f,
+	0x12, 0x5a, 0x24, 0xea, 0x94, 0xd7, 0xd5, 0x8d, 0x3a, 0x8e, 0x49, 0x74, 0x07, 0x9a, 0xdc, 0x98,
+	0x81, 0x60, 0x74, 0x2a, 0xeb, 0xca, 0x46, 0x0b, 0x37, 0x38, 0xef, 0x90, 0xb3, 0xd0, 0x6d, 0x68,
+	0x84, 0xc4, 0x22, 0xce, 0x19, 0x09, 0x07, 0x8e, 0xdd, 0xa9, 0xae, 0x2b, 0x1b, 0x75, 0x0c, 0x31,
+	0xeb, 0xc0, 0xd6, 0xff, 0x58, 0x84, 0x86, 0x30, 0x8b, 0x58, 0x7e, 0x68, 0xa3, 0x1b, 0x50, 0xa7,
+	0xce, 0x98, 0x44, 0xd4, 0x1c, 0x07, 0xdc, 0x63, 0x15, 0x4f, 0x19, 0x68, 0x0d, 0x2a, 0x72, 0xaf,
+	0x22, 0xd7, 0x24, 0x29, 0xf4, 0x13, 0x28, 0xdb, 0x4c, 0x49, 0x47, 0x5d, 0x57, 0x36, 0x1a, 0x9b,
+	0xab, 0xc6, 0xac, 0x48, 0x62, 0xf1, 0x1f, 0x16, 0x50, 0xcb, 0x25, 0x66, 0xc8, 0xbd, 0xae, 0x61,
+	0x41, 0xa0, 0xcf, 0xa0, 0x12, 0x92, 0xc8, 0xf9, 0x40, 0x3a, 0x65, 0xae, 0xa3, 0x6d, 0x60, 0x4e,
+	0xc6, 0x8b, 0xa5, 0x14, 0x6d, 0x42, 0x2d, 0xf4, 0x5d, 0x77, 0x68, 0x5a, 0xa7, 0xdc, 0xe1, 0xc6,
+	0xe6, 0x9a, 0x81, 0x25, 0x63, 0xdb, 0xf4, 0xce, 0xcc, 0x28, 0x5e, 0x91, 0xfc, 0x0f, 0x7d, 0x0d,
+	0x0d, 0x3b, 0xf4, 0x83, 0x38, 0x4e, 0x55, 0xbe, 0xac, 0x63, 0xec, 0x84, 0x7e, 0x20, 0xe2, 0xc4,
+	0x6d, 0x4d, 0x16, 0x82, 0x9d, 0x08, 0xf4, 0xc7, 0xb0, 0x3a, 0x53, 0xfb, 0xe2, 0x40, 0xe9, 0x47,
+	0xf0, 0xe9, 0x1c, 0xed, 0xa9, 0x18, 0x2a, 0x99, 0x18, 0x22, 0x28, 0x1d, 0x87, 0xfe, 0x98, 0x47,
+	0x56, 0xc5, 0xfc, 0x1b, 0xb5, 0xa1, 0x48, 0x7d, 0x1e, 0x54, 0x15, 0x17, 0xa9, 0xaf, 0xff, 0x1a,
+	0xb4, 0xd8, 0x1a, 0x4c, 0xa2, 0xc0, 0xf7, 0x22, 0xc2, 0xf0, 0xc1, 0xec, 0x0d, 0x88, 0xcd, 0x15,
+	0x96, 0x70, 0x4c, 0xa2, 0x2e, 0xd4, 0x42, 0x42, 0x4d, 0xc7, 0x23, 0x36, 0xd7, 0x5a, 0xc2, 0x09,
+	0xcd, 0xac, 0xf0, 0x5d, 0x9b, 0x44, 0x54, 0x6a, 0x97, 0x94, 0x6e, 0x80, 0xc6, 0x41, 0x78, 0xe0,
+	0x1d, 0xfb, 0xb1, 0xc5, 0x4d, 0x50, 0xce, 0xb9, 0xee, 0x16, 0x56, 0xce, 0x19, 0x75, 0xc1, 0xd5,
+	0xb5, 0xb0, 0x72, 0xa1, 0x87, 0xb0, 0x9c, 0xfa, 0xbf, 0x34, 0x49, 0x03, 0x35, 0x1c, 0x0d, 0xe5,
+	0x12, 0xf6, 0xc9, 0xa0, 0xea, 0x9a, 0x11, 0x1d, 0x4c, 0x02, 0xdb, 0xa4, 0xd2, 0x1c, 0x15, 0x37,
+	0x18, 0xef, 0x48, 0xb0, 0xd8, 0x22, 0x96, 0x65, 0xc2, 0x1c, 0xf6, 0x99, 0x8a, 0x54, 0x29, 0x1d,
+	0x29, 0x1d, 0x03, 0x4c, 0x93, 0x6d, 0x91, 0x75, 0xb1, 0x21, 0xea, 0xd4, 0x90, 0xac, 0xce, 0x56,
+	0xa2, 0xf3, 0x04, 0x6a, 0x71, 0x76, 0x2e, 0xd4, 0xb8, 0x06, 0x15, 0x97, 0x78, 0x23, 0x7a, 0x22,
+	0x95, 0x4a, 0x2a, 0xde, 0xa9, 0x34, 0x6b, 0xa7, 0x72, 0x66, 0xa7, 0x3f, 0x28, 0x72, 0xab, 0x9e,
+	0x75, 0xca, 0x0e, 0xcf, 0x0c, 0x02, 0xd7, 0x99, 0x1e, 0x9e, 0x24, 0xc5, 0xe1, 0xfd, 0x86, 0x58,
+	0x34, 0x7d, 0x78, 0x82, 0x66, 0xaa, 0xdf, 0x4d, 0xc8, 0x84, 0xd8, 0xb1, 0x11, 0x82, 0x62, 0x6b,
+	0xe8, 0x49, 0xe8, 0x53, 0xea, 0x12, 0x99, 0x5c, 0x09, 0x8d, 0x6e, 0x02, 0xf0, 0x13, 0x20, 0x61,
+	0xe8, 0x87, 0xdc, 0xa4, 0x3a, 0xae, 0x33, 0xce, 0x2e, 0x63, 0xe8, 0xff, 0x50, 0x60, 0x15, 0x13,
+	0xcf, 0x26, 0x21, 0xb1, 0x65, 0xd2, 0xca, 0xc3, 0x9c, 0x5d, 0xff, 0xee, 0x40, 0xf3, 0x38, 0x34,
+	0xc7, 0x64, 0xe0, 0x4d, 0xc6, 0x43, 0x12, 0x4a, 0x13, 0x1b, 0x9c, 0xf7, 0x9a, 0xb3, 0xb2, 0x19,
+	0xa2, 0xe6, 0x4b, 0xc9, 0x7d, 0xa8, 0x11, 0xcf, 0xf2, 0x6d, 0xc7, 0x1b, 0xc9, 0xf2, 0xd7, 0x36,
+	0xf8, 0xc6, 0xbb, 0x92, 0x8b, 0x13, 0x39, 0x33, 0xe1, 0xbd, 0x63, 0xd3, 0x13, 0x19, 0x49, 0x41,
+	0xb0, 0x28, 0x9c, 0x10, 0x67, 0x74, 0x42, 0x65, 0xe1, 0x93, 0x94, 0xfe, 0x37, 0x05, 0x56, 0x72,
+	0xae, 0x08, 0x1c, 0xa7, 0xb7, 0x54, 0xae, 0xd8, 0xb2, 0x03, 0xd5, 0x77, 0x13, 0xd3, 0x75, 0x68,
+	0x7c, 0xf6, 0x31, 0x89, 0x6e, 0x41, 0xc9, 0x0a, 0xfd, 0x40, 0x96, 0x3a, 0x30, 0x30, 0xb1, 0xa8,
+	0xe9, 0x8d, 0x5c, 0x82, 0x39, 0x9f, 0x19, 0x1b, 0x59, 0xa6, 0x3c, 0x81, 0x22, 0x16, 0x04, 0xba,
+	0x0d, 0xa5, 0x33, 0x87, 0xbc, 0xe7, 0x1e, 0xb4, 0x37, 0x1b, 0x86, 0x28, 0x26, 0xdf, 0x3b, 0xe4,
+	0x3d, 0xe6, 0x02, 0xfd, 0x2f, 0x0a, 0x34, 0xfb, 0xc4, 0x63, 0x9b, 0x73, 0x74, 0xb0, 0xdc, 0x77,
+	0x62, 0x54, 0x14, 0x9d, 0x5c, 0x65, 0x2e, 0xe6, 0xc3, 0x79, 0x13, 0x20, 0x98, 0x0c, 0x5d, 0x27,
+	0x3a, 0x19, 0x98, 0x71, 0x4e, 0xd7, 0x25, 0xa7, 0x47, 0xe7, 0xa5, 0x12, 0xe3, 0xcb, 0x6b, 0xac,
+	0xcc, 0x37, 0x92, 0x14, 0xd2, 0xa1, 0x32, 0xf4, 0x27, 0x9e, 0x1d, 0x75, 0x2a, 0x97, 0xdc, 0x94,
+	0x12, 0xfd, 0x09, 0xb4, 0xd2, 0x06, 0x47, 0xe8, 0x1e, 0x54, 0x78, 0x85, 0x8f, 0x3a, 0x0a, 0xbf,
+	0xf0, 0x5a, 0x46, 0x5a, 0x8e, 0xa5, 0x50, 0x7f, 0x01, 0x2b, 0x3b, 0x4e, 0x64, 0x99, 0xa1, 0x9d,
+	0x2d, 0x8c, 0x1a, 0xa8, 0x8e, 0x2d, 0xd6, 0x96, 0x30, 0xfb, 0x9c, 0x77, 0xdd, 0xb0, 0xa2, 0x9c,
+	0xd3, 0x20, 0xb1, 0x7a, 0x03, 0xea, 0xb6, 0x10, 0x24, 0x09, 0x35, 0x65, 0xe8, 0x47, 0x50, 0x4f,
+	0xbc, 0x58, 0x98, 0xe4, 0x09, 0xde, 0xd4, 0xd9, 0x78, 0x2b, 0x65, 0xf0, 0xf6, 0xbb, 0x14, 0xde,
+	0xde, 0x3a, 0x2e, 0x49, 0x1c, 0xba, 0x0d, 0x8d, 0xc8, 0xf1, 0x2c, 0x32, 0xe0, 0x59, 0x21, 0xed,
+	0x01, 0xce, 0xda, 0x63, 0x9c, 0x0c, 0x20, 0x8b, 0x57, 0x00, 0x32, 0x06, 0x90, 0x3a, 0x0f, 0x40,
+	0xff, 0x4e, 0x65, 0xb0, 0x34, 0x43, 0x46, 0x25, 0x9f, 0xab, 0xca, 0x15, 0xb9, 0x7a, 0x09, 0x5c,
+	0x1f, 0x15, 0x0f, 0x7e, 0x91, 0x4d, 0x5c, 0x97, 0x23, 0xaa, 0x86, 0xf9, 0x77, 0xc6, 0xd3, 0xca,
+	0x15, 0x9e, 0xde, 0x85, 0x32, 0x65, 0xf6, 0x77, 0xaa, 0x12, 0x45, 0x69, 0xaf, 0xb0, 0x90, 0xe9,
+	0x3b, 0xd0, 0x4c, 0xb3, 0x59, 0x56, 0x86, 0xc4, 0xa2, 0x1d, 0xe5, 0x12, 0x5c, 0x39, 0x7f, 0x5a,
+	0xc5, 0x8a, 0xa9, 0x2a, 0xa6, 0xff, 0x56, 0x81, 0xeb, 0x87, 0x34, 0x24, 0xe6, 0x38, 0x53, 0x30,
+	0xa2, 0x1f, 0x53, 0x31, 0x3e, 0x85, 0xea, 0xd8, 0x3c, 0x1f, 0x1c, 0x07, 0x91, 0x04, 0x52, 0x65,
+	0x6c, 0x9e, 0xef, 0x05, 0xd1, 0xd5, 0x27, 0xf7, 0x1a, 0x4a, 0xaf, 0xcc, 0xe8, 0x34, 0x95, 0xf1,
+	0x2d, 0x9e, 0xf1, 0xb1, 0x4f, 0xc5, 0x39, 0x3e, 0xad, 0x41, 0x65, 0xe8, 0xd0, 0xb1, 0x29, 0x6a,
+	0x51, 0x13, 0x4b, 0x4a, 0xef, 0x40, 0x85, 0xe9, 0x3b, 0xb0, 0xf3, 0x1a, 0xf5, 0xcf, 0xa0, 0x29,
+	0x5a, 0x92, 0x7e, 0x48, 0x8e, 0x9d, 0x73, 0x9e, 0xfe, 0xfc, 0x2b, 0xee, 0x45, 0x04, 0xa5, 0x7f,
+	0x0b, 0x4b, 0xaf, 0x7c, 0x9b, 0x84, 0x26, 0x75, 0x7c, 0xef, 0x90, 0x9a, 0x94, 0xa0, 0xeb, 0x50,
+	0x1e, 0x9b, 0xd1, 0x69, 0x9c, 0xdb, 0x65, 0x83, 0x6d, 0x81, 0x05, 0x8f, 0x55, 0xcb, 0xa1, 0xeb,
+	0x5b, 0xa7, 0xfc, 0xae, 0xe2, 0x3d, 0xaa, 0x24, 0xf5, 0x7f, 0x2a, 0xd0, 0x11, 0x0e, 0xf7, 0x4d,
+	0x86, 0x37, 0x4a, 0xc2, 0x28, 0x7d, 0xb5, 0x08, 0x5c, 0x29, 0xb3, 0x71, 0x55, 0xcc, 0xe0, 0x4a,
+	0x03, 0x95, 0x05, 0x57, 0x5e, 0xe6, 0xc7, 0x41, 0x84, 0xee, 0x42, 0x8b, 0xd7, 0xab, 0x01, 0x83,
+	0xaa, 0x3f, 0x11, 0x40, 0x2c, 0xe1, 0x26, 0x67, 0xbe, 0x15, 0x3c, 0xf4, 0xff, 0xa0, 0x39, 0xde,
+	0x88, 0x44, 0xcc, 0x95, 0x41, 0x60, 0x4e, 0x22, 0x62, 0x4b, 0x68, 0x2e, 0x25, 0xfc, 0x3e, 0x67,
+	0xa3, 0x7b, 0xd0, 0xb6, 0x9d, 0x28, 0x70, 0xcd, 0x8b, 0xc1, 0x71, 0xe8, 0x7f, 0x20, 0x1e, 0xc7,
+	0x6a, 0x0d, 0xb7, 0x24, 0x77, 0x8f, 0x33, 0xf5, 0x5d, 0x58, 0xde, 0x73, 0x5c, 0x17, 0x93, 0x91,
+	0xe3, 0x7b, 0x31, 0x54, 0xae, 0x02, 0xa0, 0x6c, 0x10, 0x8a, 0x49, 0x83, 0xa0, 0x3f, 0x83, 0x4f,
+	0xfa, 0x29, 0x43, 0x63, 0x45, 0x97, 0x9c, 0x52, 0x2e, 0x3b, 0xa5, 0xdf, 0x02, 0xd8, 0x0b, 0xd2,
+	0x95, 0x93, 0x45, 0x46, 0x49, 0x22, 0xa3, 0xdf, 0x85, 0xc6, 0x4b, 0x7f, 0xe4, 0x2f, 0x9c, 0x61,
+	0x18, 0x1a, 0xb8, 0xe3, 0xa9, 0xce, 0x54, 0xc6, 0x47, 0xe1, 0x6e, 0x4b, 0x4a, 0xff, 0xbb, 0x02,
+	0x4b, 0x58, 0x8e, 0x0c, 0xaf, 0xcc, 0x20, 0x10, 0xe5, 0x28, 0x33, 0x58, 0x28, 0xf9, 0xc1, 0x62,
+	0x7a, 0xb6, 0xc5, 0xd9, 0x67, 0xab, 0x66, 0xce, 0x96, 0x57, 0x63, 0x76, 0x7a, 0xe5, 0xa4, 0x1a,
+	0x97, 0x05, 0x75, 0x31, 0xbd, 0x50, 0x2b, 0xe9, 0x0b, 0x95, 0x5d, 0xc3, 0xae, 0x13, 0x74, 0xaa,
+	0x97, 0xe3, 0xcd, 0xf8, 0xac, 0x0a, 0x05, 0x84, 0x84, 0x9d, 0x1a, 0xb7, 0x8c, 0x7f, 0xeb, 0x0f,
+	0x00, 0xf0, 0xd4, 0xc2, 0xab, 0x5c, 0xd0, 0x5f, 0x80, 0x96, 0x73, 0x3b, 0x42, 0x5f, 0x40, 0x6d,
+	0x2c, 0xbf, 0x65, 0x26, 0x68, 0x46, 0xee, 0x4f, 0x38, 0xf9, 0x87, 0x6e, 0x43, 0x2b, 0x33, 0xc5,
+	0x7c, 0x24, 0xe2, 0xef, 0x41, 0x25, 0xf0, 0x5d, 0xc7, 0xba, 0x90, 0xb5, 0xa3, 0x25, 0x67, 0xa2,
+	0x3e, 0x67, 0x62, 0x29, 0xd4, 0x3f, 0x87, 0xd6, 0x5e, 0x48, 0xc8, 0x87, 0xf4, 0x41, 0x4a, 0xfc,
+	0xca, 0x83, 0x14, 0x94, 0xfe, 0x7b, 0x05, 0x9a, 0xb1, 0xb1, 0xac, 0x61, 0x4f, 0xd5, 0x87, 0x3a,
+	0xaf, 0x38, 0x1a, 0xa8, 0x63, 0xd3, 0x92, 0xb7, 0x2d, 0xfb, 0x64, 0x99, 0x7d, 0x46, 0xc2, 0xc8,
+	0xf1, 0x3d, 0x6e, 0x43, 0x1d, 0xc7, 0x64, 0xaa, 0x76, 0x94, 0xd2, 0xb5, 0x03, 0xe9, 0xd0, 0xb4,
+	0xcc, 0xc0, 0x1c, 0x3a, 0xae, 0x43, 0x9d, 0x64, 0x68, 0xcd, 0xf0, 0xf4, 0xf7, 0xd0, 0x61, 0xd9,
+	0x13, 0x51, 0x12, 0xc6, 0xf6, 0x24, 0x45, 0xe1, 0x01, 0xa0, 0x13, 0x62, 0x86, 0x74, 0x48, 0x4c,
+	0x3a, 0x70, 0x3c, 0x4a, 0xc2, 0x33, 0xd3, 0x95, 0x13, 0xd6, 0x72, 0x22, 0x39, 0x90, 0x82, 0x8f,
+	0xc3, 0x99, 0xfe, 0x25, 0x68, 0xdf, 0xc6, 0x2a, 0x52, 0xd7, 0xf4, 0x62, 0x1c, 0xfc, 0x4b, 0x81,
+	0x76, 0x6c, 0x26, 0x2b, 0x86, 0x13, 0x36, 0x7a, 0x97, 0x1c, 0xef, 0xd8, 0x97, 0xd9, 0xde, 0x32,
+	0xd2, 0x51, 0xc5, 0x5c, 0xc4, 0x5b, 0x7b, 0xdb, 0x0e, 0x49, 0x14, 0xc9, 0x78, 0xc6, 0x24, 0xba,
+	0x05, 0x10, 0x4a, 0xef, 0x65, 0x0b, 0xaf, 0xe2, 0x14, 0x07, 0x5d, 0x07, 0xde, 0x98, 0x0f, 0x22,
+	0x42, 0x3c, 0x1e, 0x5c, 0x15, 0xd7, 0x18, 0xe3, 0x90, 0x10, 0x1e, 0x76, 0xd9, 0x64, 0xc9, 0x8e,
+	0x4d, 0x50, 0xac, 0x01, 0xe4, 0x5f, 0x83, 0xd0, 0xa4, 0x22, 0x55, 0x14, 0x5c, 0xe7, 0x1c, 0xcc,
+	0xca, 0x37, 0x4b, 0x22, 0xca, 0x92, 0xa8, 0x2a, 0x86, 0x6e, 0x4e, 0xe8, 0xcf, 0xa7, 0x78, 0x78,
+	0xe9, 0x44, 0x14, 0x3d, 0x80, 0x7a, 0xec, 0x77, 0x0c, 0xef, 0x25, 0x23, 0xeb, 0x3a, 0x9e, 0xfe,
+	0x43, 0xff, 0x73, 0x11, 0xb4, 0x57, 0x84, 0x86, 0x8e, 0x15, 0xed, 0x98, 0xd4, 0x0c, 0x7c, 0xc7,
+	0xa3, 0x6c, 0x08, 0x71, 0x02, 0xd3, 0x3a, 0x25, 0x34, 0x92, 0xa5, 0x2b, 0xa1, 0x99, 0xcc, 0x8f,
+	0x65, 0x72, 0xa8, 0xf1, 0x53, 0x32, 0x3b, 0x96, 0xa9, 0x42, 0x16, 0xd3, 0xcc, 0x69, 0x67, 0x78,
+	0x41, 0x49, 0x24, 0x2b, 0xbc, 0xa4, 0x18, 0xdf, 0x17, 0x7c, 0x19, 0x0c, 0x41, 0xc5, 0x38, 0xae,
+	0x4c, 0x71, 0xdc, 0x03, 0x70, 0x02, 0xcb, 0x9f, 0x30, 0xd8, 0xc4, 0x9d, 0xc5, 0x1d, 0x23, 0x6f,
+	0xbc, 0x71, 0x90, 0xfc, 0x67, 0xd7, 0xa3, 0xe1, 0x05, 0x4e, 0x2d, 0xea, 0x3e, 0x87, 0xa5, 0x9c,
+	0x98, 0xed, 0x73, 0x4a, 0x2e, 0x24, 0x64, 0xd8, 0x27, 0x8b, 0xf3, 0x99, 0xe9, 0x4e, 0x88, 0x74,
+	0x4f, 0x10, 0xcf, 0x8a, 0x4f, 0x95, 0xfb, 0x4f, 0xa1, 0x91, 0x7a, 0xde, 0x41, 0x35, 0x28, 0x6d,
+	0xed, 0xe3, 0x9e, 0x56, 0x60, 0x5f, 0x78, 0x7f, 0xab, 0xa7, 0x29, 0xa8, 0x0e, 0x65, 0xbc, 0xbf,
+	0xb5, 0xf9, 0x95, 0x56, 0x44, 0x00, 0x15, 0xbc, 0xbf, 0xf5, 0xf8, 0xc9, 0x63, 0x4d, 0xbd, 0xff,
+	0x39, 0xc0, 0xb4, 0x67, 0x60, 0x92, 0xfe, 0xd1, 0xd6, 0xcb, 0x83, 0x6d, 0xad, 0x80, 0x5a, 0x50,
+	0x7f, 0xf5, 0x66, 0x67, 0x17, 0xf7, 0xde, 0xbe, 0xc1, 0x9a, 0x72, 0xdf, 0x85, 0x56, 0xa6, 0x3b,
+	0x61, 0xaa, 0xbf, 0xeb, 0xef, 0xee, 0x6b, 0x05, 0x54, 0x05, 0xb5, 0xff, 0x7a, 0x5f, 0x53, 0x50,
+	0x13, 0x6a, 0xb8, 0xf7, 0xc3, 0x80, 0xef, 0x58, 0x44, 0x0d, 0xa8, 0x4a, 0x4a, 0x53, 0x63, 0x11,
+	0x37, 0xab, 0x84, 0xda, 0x00, 0x52, 0xc4, 0xac, 0x28, 0xa3, 0x25, 0x68, 0x30, 0xfa, 0x57, 0x47,
+	0xdf, 0x7f, 0xb5, 0xf9, 0xa8, 0xaf, 0x55, 0xee, 0x7f, 0x01, 0xcd, 0x74, 0x39, 0x62, 0x9b, 0x6d,
+	0xe3, 0x37, 0x7d, 0xb9, 0x59, 0x6f, 0x47, 0x38, 0x74, 0xb8, 0xdd, 0x7b, 0xb9, 0xab, 0x15, 0x37,
+	0xff, 0x5a, 0x81, 0xe5, 0xc3, 0xf8, 0x61, 0x4e, 0x76, 0x5b, 0x21, 0x7a, 0x01, 0xad, 0xcc, 0x53,
+	0x11, 0x9a, 0xfd, 0x74, 0xd4, 0x5d, 0x33, 0xc4, 0xdb, 0x9d, 0x11, 0xbf, 0xdd, 0x19, 0xbb, 0xec,
+	0xed, 0x4e, 0x2f, 0xa0, 0xef, 0xe0, 0x93, 0x7d, 0x42, 0xf3, 0x2d, 0x06, 0x9a, 0xb3, 0xa0, 0x7b,
+	0xcd, 0x98, 0xd7, 0x8d, 0xe8, 0x05, 0xf4, 0x1a, 0x56, 0x7f, 0x30, 0xa9, 0x75, 0xf2, 0x3f, 0xd1,
+	0xf6, 0x48, 0x41, 0xbf, 0x80, 0x96, 0x44, 0x98, 0x78, 0xe4, 0x40, 0xcb, 0x97, 0x10, 0xb7, 0xc0,
+	0xb3, 0x6d, 0xd0, 0xf6, 0x09, 0xcd, 0x34, 0xa6, 0x68, 0xd5, 0x98, 0x35, 0xd9, 0x76, 0xd7, 0x8c,
+	0x99, 0xb3, 0xbb, 0x5e, 0x40, 0x7d, 0x58, 0x99, 0xd5, 0xe0, 0xa2, 0x1b, 0xc6, 0x82, 0xbe, 0x77,
+	0xbe, 0xbe, 0x47, 0x4a, 0xce, 0x2c, 0x3e, 0x69, 0xa4, 0xcc, 0x4a, 0x0f, 0x40, 0xdd, 0xb5, 0x3c,
+	0x3b, 0x31, 0xeb, 0x09, 0x34, 0xc5, 0xfe, 0x72, 0x74, 0x9c, 0x73, 0xec, 0xf2, 0xc9, 0xb4, 0x67,
+	0x9d, 0xea, 0x85, 0x0d, 0xe5, 0x91, 0x82, 0x7e, 0x06, 0xcd, 0x7d, 0x42, 0x93, 0x17, 0x27, 0xb4,
+	0x6c, 0xe4, 0x5f, 0xab, 0xba, 0xc8, 0xb8, 0xf4, 0x20, 0xa5, 0x17, 0xd0, 0x37, 0xec, 0x2e, 0xcf,
+	0xde, 0x38, 0x28, 0x5b, 0xb6, 0xbb, 0xd7, 0x8c, 0x79, 0x77, 0x92, 0x5e, 0x40, 0x4f, 0xa1, 0x9e,
+	0x5c, 0x1c, 0x68, 0xd9, 0xc8, 0x5f, 0x22, 0x0b, 0x8e, 0xf1, 0x29, 0xb4, 0x58, 0x6d, 0x8d, 0x75,
+	0xce, 0x07, 0xd3, 0xd4, 0x1c, 0xf6, 0x7f, 0xbd, 0xb0, 0xf9, 0xa7, 0x3a, 0xb4, 0x93, 0x94, 0xe9,
+	0xd9, 0x63, 0xc7, 0x43, 0xbf, 0x84, 0xc6, 0xb6, 0x4b, 0xcc, 0x50, 0xc0, 0x6e, 0xae, 0xaa, 0xf9,
+	0xd6, 0x3c, 0x03, 0x98, 0xf6, 0xae, 0x08, 0x19, 0x97, 0x1a, 0xd9, 0x05, 0x6b, 0x7b, 0xb0, 0x74,
+	0x28, 0x83, 0x1f, 0x37, 0xd7, 0x2b, 0xc6, 0x8c, 0x16, 0x76, 0x81, 0x8a, 0x87, 0x50, 0x39, 0x24,
+	0x94, 0x4d, 0x45, 0x0d, 0x63, 0xda, 0xc0, 0x2e, 0x58, 0xf0, 0x53, 0xa8, 0x1e, 0x12, 0xca, 0x7a,
+	0x59, 0xd4, 0x34, 0x52, 0x2d, 0xed, 0x82, 0x25, 0x5f, 0x43, 0x9b, 0xb7, 0xb5, 0x07, 0x71, 0x77,
+	0x8f, 0x5a, 0x46, 0xba, 0xcf, 0x5d, 0xb8, 0x54, 0x76, 0x52, 0x3b, 0xa2, 0xe1, 0x47, 0x6d, 0x23,
+	0xd3, 0x59, 0x2d, 0x3c, 0x66, 0x59, 0x0d, 0xe5, 0xd1, 0xe4, 0xde, 0xaf, 0x17, 0xac, 0x7c, 0x01,
+	0xe8, 0x90, 0xd0, 0x7c, 0x83, 0x7d, 0xa9, 0xad, 0x5c, 0xa0, 0xe1, 0x1b, 0x36, 0xf9, 0x8f, 0xfd,
+	0x33, 0x92, 0x57, 0xd2, 0x98, 0x22, 0xdc, 0x5e, 0x58, 0x69, 0x56, 0xd2, 0x10, 0x4d, 0x9a, 0xdd,
+	0x79, 0xf0, 0x5a, 0xce, 0xdb, 0x16, 0xe9, 0x05, 0xf4, 0x1c, 0xda, 0xd9, 0x97, 0x72, 0x34, 0xe7,
+	0x61, 0xbe, 0xbb, 0x9c, 0xf0, 0x53, 0x09, 0xd6, 0x03, 0x2d, 0xff, 0x62, 0x8e, 0xe6, 0x3e, 0xd1,
+	0xcf, 0x56, 0x71, 0x0d, 0xaa, 0x3d, 0xdb, 0xe6, 0xa3, 0xb4, 0x18, 0x4f, 0xbb, 0xe2, 0x87, 0xe3,
+	0x0e, 0x44, 0x84, 0xb8, 0xb4, 0x6a, 0x88, 0xf9, 0x78, 0x41, 0x48, 0x9e, 0x40, 0x63, 0x8b, 0x8d,
+	0xb0, 0x62, 0x73, 0xd4, 0x32, 0xd2, 0x73, 0xf3, 0xe2, 0x6c, 0x3f, 0xf2, 0x86, 0x3f, 0x66, 0xe5,
+	0xcf, 0xa1, 0xb5, 0x4f, 0xe8, 0x74, 0xec, 0x9e, 0x1b, 0x7d, 0xcd, 0xc8, 0xcd, 0xe6, 0x3c, 0xf8,
+	0xcb, 0xec, 0x04, 0xb3, 0xef, 0x71, 0xf3, 0x14, 0xb4, 0x33, 0xef, 0x72, 0x11, 0x87, 0x60, 0x2b,
+	0xf3, 0xa0, 0x86, 0x56, 0x8d, 0x59, 0x4f, 0x74, 0xdd, 0x35, 0x63, 0xe6, 0xbb, 0x9b, 0x5e, 0x18,
+	0x56, 0xf8, 0x1e, 0x5f, 0xfe, 0x77, 0x00, 0x33, 0x3d, 0x17, 0xff, 0x7d, 0x1b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SixelpingRendererClient is the client API for SixelpingRenderer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SixelpingRendererClient interface {
+	NewDeltaImage(ctx context.Context, in *NewDeltaImageRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CanvasParametersResponse, error)
+	WatchCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SixelpingRenderer_WatchCanvasParametersClient, error)
+	MetricsUpdate(ctx context.Context, in *MetricsDatapoint, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetRenderedImage(ctx context.Context, in *RenderedImageRequest, opts ...grpc.CallOption) (*RenderedImageResponse, error)
+	StreamRenderedImages(ctx context.Context, in *StreamRenderedImagesRequest, opts ...grpc.CallOption) (SixelpingRenderer_StreamRenderedImagesClient, error)
+	GetRenderedTiles(ctx context.Context, in *RenderedTilesRequest, opts ...grpc.CallOption) (*RenderedTilesResponse, error)
+	StreamDeltas(ctx context.Context, opts ...grpc.CallOption) (SixelpingRenderer_StreamDeltasClient, error)
+	GetPixelInfo(ctx context.Context, in *PixelInfoRequest, opts ...grpc.CallOption) (*PixelInfoResponse, error)
+	RegisterReceiver(ctx context.Context, in *ReceiverInfo, opts ...grpc.CallOption) (*RegisterReceiverResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListReceivers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReceiverList, error)
+}
+
+type sixelpingRendererClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSixelpingRendererClient(cc *grpc.ClientConn) SixelpingRendererClient {
+	return &sixelpingRendererClient{cc}
+}
+
+func (c *sixelpingRendererClient) NewDeltaImage(ctx context.Context, in *NewDeltaImageRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/NewDeltaImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) GetCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CanvasParametersResponse, error) {
+	out := new(CanvasParametersResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetCanvasParameters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) WatchCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (SixelpingRenderer_WatchCanvasParametersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SixelpingRenderer_serviceDesc.Streams[0], "/SixelpingRenderer/WatchCanvasParameters", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sixelpingRendererWatchCanvasParametersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SixelpingRenderer_WatchCanvasParametersClient interface {
+	Recv() (*CanvasParametersResponse, error)
+	grpc.ClientStream
+}
+
+type sixelpingRendererWatchCanvasParametersClient struct {
+	grpc.ClientStream
+}
+
+func (x *sixelpingRendererWatchCanvasParametersClient) Recv() (*CanvasParametersResponse, error) {
+	m := new(CanvasParametersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sixelpingRendererClient) MetricsUpdate(ctx context.Context, in *MetricsDatapoint, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/MetricsUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) GetRenderedImage(ctx context.Context, in *RenderedImageRequest, opts ...grpc.CallOption) (*RenderedImageResponse, error) {
+	out := new(RenderedImageResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetRenderedImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) StreamRenderedImages(ctx context.Context, in *StreamRenderedImagesRequest, opts ...grpc.CallOption) (SixelpingRenderer_StreamRenderedImagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SixelpingRenderer_serviceDesc.Streams[1], "/SixelpingRenderer/StreamRenderedImages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sixelpingRendererStreamRenderedImagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SixelpingRenderer_StreamRenderedImagesClient interface {
+	Recv() (*RenderedImageResponse, error)
+	grpc.ClientStream
+}
+
+type sixelpingRendererStreamRenderedImagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *sixelpingRendererStreamRenderedImagesClient) Recv() (*RenderedImageResponse, error) {
+	m := new(RenderedImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sixelpingRendererClient) GetRenderedTiles(ctx context.Context, in *RenderedTilesRequest, opts ...grpc.CallOption) (*RenderedTilesResponse, error) {
+	out := new(RenderedTilesResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetRenderedTiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) StreamDeltas(ctx context.Context, opts ...grpc.CallOption) (SixelpingRenderer_StreamDeltasClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SixelpingRenderer_serviceDesc.Streams[2], "/SixelpingRenderer/StreamDeltas", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sixelpingRendererStreamDeltasClient{stream}
+	return x, nil
+}
+
+type SixelpingRenderer_StreamDeltasClient interface {
+	Send(*NewDeltaImageRequest) error
+	Recv() (*DeltaAck, error)
+	grpc.ClientStream
+}
+
+type sixelpingRendererStreamDeltasClient struct {
+	grpc.ClientStream
+}
+
+func (x *sixelpingRendererStreamDeltasClient) Send(m *NewDeltaImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *sixelpingRendererStreamDeltasClient) Recv() (*DeltaAck, error) {
+	m := new(DeltaAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sixelpingRendererClient) GetPixelInfo(ctx context.Context, in *PixelInfoRequest, opts ...grpc.CallOption) (*PixelInfoResponse, error) {
+	out := new(PixelInfoResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetPixelInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) RegisterReceiver(ctx context.Context, in *ReceiverInfo, opts ...grpc.CallOption) (*RegisterReceiverResponse, error) {
+	out := new(RegisterReceiverResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/RegisterReceiver", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/Heartbeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingRendererClient) ListReceivers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReceiverList, error) {
+	out := new(ReceiverList)
+	err := c.cc.Invoke(ctx, "/SixelpingRenderer/ListReceivers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SixelpingRendererServer is the server API for SixelpingRenderer service.
+type SixelpingRendererServer interface {
+	NewDeltaImage(context.Context, *NewDeltaImageRequest) (*empty.Empty, error)
+	GetCanvasParameters(context.Context, *empty.Empty) (*CanvasParametersResponse, error)
+	WatchCanvasParameters(*empty.Empty, SixelpingRenderer_WatchCanvasParametersServer) error
+	MetricsUpdate(context.Context, *MetricsDatapoint) (*empty.Empty, error)
+	GetRenderedImage(context.Context, *RenderedImageRequest) (*RenderedImageResponse, error)
+	StreamRenderedImages(*StreamRenderedImagesRequest, SixelpingRenderer_StreamRenderedImagesServer) error
+	GetRenderedTiles(context.Context, *RenderedTilesRequest) (*RenderedTilesResponse, error)
+	StreamDeltas(SixelpingRenderer_StreamDeltasServer) error
+	GetPixelInfo(context.Context, *PixelInfoRequest) (*PixelInfoResponse, error)
+	RegisterReceiver(context.Context, *ReceiverInfo) (*RegisterReceiverResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*empty.Empty, error)
+	ListReceivers(context.Context, *empty.Empty) (*ReceiverList, error)
+}
+
+// UnimplementedSixelpingRendererServer can be embedded to have forward compatible implementations.
+type UnimplementedSixelpingRendererServer struct {
+}
+
+func (*UnimplementedSixelpingRendererServer) NewDeltaImage(ctx context.Context, req *NewDeltaImageRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewDeltaImage not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) GetCanvasParameters(ctx context.Context, req *empty.Empty) (*CanvasParametersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCanvasParameters not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) WatchCanvasParameters(req *empty.Empty, srv SixelpingRenderer_WatchCanvasParametersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCanvasParameters not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) MetricsUpdate(ctx context.Context, req *MetricsDatapoint) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsUpdate not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) GetRenderedImage(ctx context.Context, req *RenderedImageRequest) (*RenderedImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRenderedImage not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) StreamRenderedImages(req *StreamRenderedImagesRequest, srv SixelpingRenderer_StreamRenderedImagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRenderedImages not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) GetRenderedTiles(ctx context.Context, req *RenderedTilesRequest) (*RenderedTilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRenderedTiles not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) StreamDeltas(srv SixelpingRenderer_StreamDeltasServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDeltas not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) GetPixelInfo(ctx context.Context, req *PixelInfoRequest) (*PixelInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPixelInfo not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) RegisterReceiver(ctx context.Context, req *ReceiverInfo) (*RegisterReceiverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterReceiver not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) Heartbeat(ctx context.Context, req *HeartbeatRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (*UnimplementedSixelpingRendererServer) ListReceivers(ctx context.Context, req *empty.Empty) (*ReceiverList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReceivers not implemented")
+}
+
+func RegisterSixelpingRendererServer(s *grpc.Server, srv SixelpingRendererServer) {
+	s.RegisterService(&_SixelpingRenderer_serviceDesc, srv)
+}
+
+func _SixelpingRenderer_NewDeltaImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewDeltaImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).NewDeltaImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/NewDeltaImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).NewDeltaImage(ctx, req.(*NewDeltaImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_GetCanvasParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).GetCanvasParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/GetCanvasParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).GetCanvasParameters(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_WatchCanvasParameters_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SixelpingRendererServer).WatchCanvasParameters(m, &sixelpingRendererWatchCanvasParametersServer{stream})
+}
+
+type SixelpingRenderer_WatchCanvasParametersServer interface {
+	Send(*CanvasParametersResponse) error
+	grpc.ServerStream
+}
+
+type sixelpingRendererWatchCanvasParametersServer struct {
+	grpc.ServerStream
+}
+
+func (x *sixelpingRendererWatchCanvasParametersServer) Send(m *CanvasParametersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SixelpingRenderer_MetricsUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsDatapoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).MetricsUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/MetricsUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).MetricsUpdate(ctx, req.(*MetricsDatapoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_GetRenderedImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderedImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).GetRenderedImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/GetRenderedImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).GetRenderedImage(ctx, req.(*RenderedImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_StreamRenderedImages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRenderedImagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SixelpingRendererServer).StreamRenderedImages(m, &sixelpingRendererStreamRenderedImagesServer{stream})
+}
+
+type SixelpingRenderer_StreamRenderedImagesServer interface {
+	Send(*RenderedImageResponse) error
+	grpc.ServerStream
+}
+
+type sixelpingRendererStreamRenderedImagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *sixelpingRendererStreamRenderedImagesServer) Send(m *RenderedImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SixelpingRenderer_GetRenderedTiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderedTilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).GetRenderedTiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/GetRenderedTiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).GetRenderedTiles(ctx, req.(*RenderedTilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_StreamDeltas_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SixelpingRendererServer).StreamDeltas(&sixelpingRendererStreamDeltasServer{stream})
+}
+
+type SixelpingRenderer_StreamDeltasServer interface {
+	Send(*DeltaAck) error
+	Recv() (*NewDeltaImageRequest, error)
+	grpc.ServerStream
+}
+
+type sixelpingRendererStreamDeltasServer struct {
+	grpc.ServerStream
+}
+
+func (x *sixelpingRendererStreamDeltasServer) Send(m *DeltaAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *sixelpingRendererStreamDeltasServer) Recv() (*NewDeltaImageRequest, error) {
+	m := new(NewDeltaImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SixelpingRenderer_GetPixelInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PixelInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).GetPixelInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/GetPixelInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).GetPixelInfo(ctx, req.(*PixelInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_RegisterReceiver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).RegisterReceiver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/RegisterReceiver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).RegisterReceiver(ctx, req.(*ReceiverInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingRenderer_ListReceivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingRendererServer).ListReceivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingRenderer/ListReceivers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingRendererServer).ListReceivers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SixelpingRenderer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "SixelpingRenderer",
+	HandlerType: (*SixelpingRendererServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NewDeltaImage",
+			Handler:    _SixelpingRenderer_NewDeltaImage_Handler,
+		},
+		{
+			MethodName: "GetCanvasParameters",
+			Handler:    _SixelpingRenderer_GetCanvasParameters_Handler,
+		},
+		{
+			MethodName: "MetricsUpdate",
+			Handler:    _SixelpingRenderer_MetricsUpdate_Handler,
+		},
+		{
+			MethodName: "GetRenderedImage",
+			Handler:    _SixelpingRenderer_GetRenderedImage_Handler,
+		},
+		{
+			MethodName: "GetRenderedTiles",
+			Handler:    _SixelpingRenderer_GetRenderedTiles_Handler,
+		},
+		{
+			MethodName: "GetPixelInfo",
+			Handler:    _SixelpingRenderer_GetPixelInfo_Handler,
+		},
+		{
+			MethodName: "RegisterReceiver",
+			Handler:    _SixelpingRenderer_RegisterReceiver_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _SixelpingRenderer_Heartbeat_Handler,
+		},
+		{
+			MethodName: "ListReceivers",
+			Handler:    _SixelpingRenderer_ListReceivers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCanvasParameters",
+			Handler:       _SixelpingRenderer_WatchCanvasParameters_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRenderedImages",
+			Handler:       _SixelpingRenderer_StreamRenderedImages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamDeltas",
+			Handler:       _SixelpingRenderer_StreamDeltas_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "sixelping-command.proto",
+}
 
-var fileDescriptor_bc675ceef4b1ed56 = []byte{
-	// 428 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x86, 0x13, 0xa7, 0x0d, 0x74, 0x20, 0x22, 0x5d, 0xda, 0x60, 0xcc, 0x25, 0xf8, 0x94, 0x03,
-	0xdd, 0x4a, 0xe5, 0x82, 0x10, 0x48, 0x20, 0x52, 0x55, 0x45, 0x02, 0x21, 0x23, 0x2e, 0xdc, 0x36,
-	0xf6, 0xd4, 0x59, 0x35, 0xde, 0x5d, 0xbc, 0x93, 0x96, 0x3c, 0x10, 0x17, 0x9e, 0x12, 0xed, 0xae,
-	0x5d, 0xa5, 0xa1, 0xe9, 0x6d, 0xbe, 0xf9, 0x67, 0xec, 0xd9, 0x99, 0x1f, 0x9e, 0x59, 0xf9, 0x1b,
-	0x17, 0x46, 0xaa, 0xf2, 0x28, 0xd7, 0x55, 0x25, 0x54, 0xc1, 0x4d, 0xad, 0x49, 0x27, 0x2f, 0x4a,
-	0xad, 0xcb, 0x05, 0x1e, 0x7b, 0x9a, 0x2d, 0x2f, 0x8e, 0xb1, 0x32, 0xb4, 0x0a, 0x62, 0xfa, 0x0a,
-	0x0e, 0xbe, 0xe2, 0xf5, 0x14, 0x17, 0x24, 0xce, 0x2b, 0x51, 0x62, 0x86, 0xbf, 0x96, 0x68, 0x89,
-	0x1d, 0xc0, 0xae, 0x74, 0x1c, 0x77, 0xc7, 0xdd, 0xc9, 0xe3, 0x2c, 0x40, 0x7a, 0x04, 0x87, 0x19,
-	0xaa, 0x02, 0x6b, 0x2c, 0x9a, 0x6a, 0x6b, 0xb4, 0xb2, 0xb8, 0xa5, 0xfc, 0x27, 0xc4, 0x9f, 0x84,
-	0xba, 0x12, 0xf6, 0x9b, 0xa8, 0x45, 0x85, 0x84, 0xb5, 0x5d, 0xef, 0xb8, 0x96, 0x05, 0xcd, 0x7d,
-	0xc7, 0x20, 0x0b, 0xc0, 0x46, 0xd0, 0x9f, 0xa3, 0x2c, 0xe7, 0x14, 0x47, 0x3e, 0xdd, 0x10, 0x1b,
-	0x42, 0xef, 0xc2, 0xd8, 0xb8, 0xe7, 0x93, 0x2e, 0x4c, 0xff, 0x46, 0x30, 0xfc, 0x82, 0x54, 0xcb,
-	0xdc, 0x4e, 0x05, 0x09, 0xa3, 0xa5, 0x22, 0x96, 0xc0, 0x43, 0x69, 0x44, 0x7e, 0x89, 0x64, 0xfd,
-	0x77, 0x77, 0xb2, 0x1b, 0x76, 0x9a, 0x6e, 0xb5, 0x28, 0x68, 0x7a, 0x4d, 0x2b, 0x5a, 0xad, 0x17,
-	0xb4, 0x96, 0xdd, 0x48, 0x72, 0xb6, 0x22, 0xb4, 0xf1, 0x8e, 0x57, 0x1a, 0x72, 0x79, 0x1d, 0xf2,
-	0xbb, 0x21, 0x1f, 0xc8, 0x8d, 0x5a, 0x89, 0x3c, 0xee, 0x8f, 0xbb, 0x93, 0xbd, 0xcc, 0x85, 0xec,
-	0x23, 0x80, 0x34, 0xb9, 0x5e, 0x2a, 0xb7, 0x80, 0xf8, 0xc1, 0xb8, 0x37, 0x79, 0x74, 0xf2, 0x92,
-	0x6f, 0x0e, 0xcf, 0xcf, 0x6f, 0x6a, 0x4e, 0x15, 0xd5, 0xab, 0x6c, 0xad, 0x29, 0x79, 0x0f, 0x4f,
-	0x36, 0x64, 0xf7, 0x9f, 0x4b, 0x5c, 0xf9, 0x67, 0xee, 0x65, 0x2e, 0x74, 0x2b, 0xbd, 0x12, 0x8b,
-	0x25, 0x36, 0xcf, 0x0b, 0xf0, 0x36, 0x7a, 0xd3, 0x3d, 0xf9, 0x13, 0xc1, 0xfe, 0xf7, 0xd6, 0x1e,
-	0xcd, 0x05, 0x6b, 0xf6, 0x01, 0x06, 0xb7, 0x6e, 0xcf, 0x0e, 0xf9, 0x5d, 0x5e, 0x48, 0x46, 0x3c,
-	0x38, 0x88, 0xb7, 0x0e, 0xe2, 0xa7, 0xce, 0x41, 0x69, 0x87, 0x7d, 0x86, 0xa7, 0x67, 0x48, 0x9b,
-	0x37, 0x66, 0x5b, 0x1a, 0x92, 0xe7, 0x7c, 0x9b, 0x1d, 0xd2, 0x0e, 0x7b, 0x07, 0x83, 0x66, 0x25,
-	0x3f, 0x4c, 0x21, 0x08, 0xd9, 0xfe, 0x7f, 0x2b, 0xba, 0x67, 0x92, 0x29, 0x0c, 0xcf, 0x90, 0x6e,
-	0x99, 0x73, 0xeb, 0x18, 0x23, 0x7e, 0xa7, 0x89, 0xd3, 0xce, 0xac, 0xef, 0x2b, 0x5f, 0xff, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0xe5, 0x5a, 0x31, 0x64, 0x4c, 0x03, 0x00, 0x00,
+// SixelpingAdminClient is the client API for SixelpingAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SixelpingAdminClient interface {
+	ClearCanvas(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	FillRegion(ctx context.Context, in *FillRegionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetPixelTimeout(ctx context.Context, in *PixelTimeoutRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetFps(ctx context.Context, in *FpsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetLogo(ctx context.Context, in *LogoRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	PauseIngestion(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	FreezeDisplay(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ResizeCanvas(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetReceiverMapping(ctx context.Context, in *ReceiverMapping, opts ...grpc.CallOption) (*empty.Empty, error)
+	RemoveReceiverMapping(ctx context.Context, in *ReceiverId, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListReceiverMappings(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReceiverMappings, error)
+	RollbackCanvas(ctx context.Context, in *RollbackCanvasRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	DropSourceDeltas(ctx context.Context, in *DropSourceDeltasRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	AddMask(ctx context.Context, in *Mask, opts ...grpc.CallOption) (*Mask, error)
+	RemoveMask(ctx context.Context, in *MaskId, opts ...grpc.CallOption) (*empty.Empty, error)
+	BlockSource(ctx context.Context, in *SourcePrefix, opts ...grpc.CallOption) (*empty.Empty, error)
+	UnblockSource(ctx context.Context, in *SourcePrefix, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetModeration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ModerationState, error)
+	ListPendingDeltas(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PendingDeltas, error)
+	DiscardDeltas(ctx context.Context, in *DiscardDeltasRequest, opts ...grpc.CallOption) (*DiscardDeltasResponse, error)
+}
+
+type sixelpingAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSixelpingAdminClient(cc *grpc.ClientConn) SixelpingAdminClient {
+	return &sixelpingAdminClient{cc}
+}
+
+func (c *sixelpingAdminClient) ClearCanvas(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/ClearCanvas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) FillRegion(ctx context.Context, in *FillRegionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/FillRegion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) SetPixelTimeout(ctx context.Context, in *PixelTimeoutRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/SetPixelTimeout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) SetFps(ctx context.Context, in *FpsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/SetFps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) SetLogo(ctx context.Context, in *LogoRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/SetLogo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) PauseIngestion(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/PauseIngestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) FreezeDisplay(ctx context.Context, in *FreezeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/FreezeDisplay", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) ResizeCanvas(ctx context.Context, in *ResizeRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/ResizeCanvas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) SetReceiverMapping(ctx context.Context, in *ReceiverMapping, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/SetReceiverMapping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) RemoveReceiverMapping(ctx context.Context, in *ReceiverId, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/RemoveReceiverMapping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) ListReceiverMappings(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReceiverMappings, error) {
+	out := new(ReceiverMappings)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/ListReceiverMappings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) RollbackCanvas(ctx context.Context, in *RollbackCanvasRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/RollbackCanvas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) DropSourceDeltas(ctx context.Context, in *DropSourceDeltasRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	out := new(RollbackResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/DropSourceDeltas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) AddMask(ctx context.Context, in *Mask, opts ...grpc.CallOption) (*Mask, error) {
+	out := new(Mask)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/AddMask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) RemoveMask(ctx context.Context, in *MaskId, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/RemoveMask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) BlockSource(ctx context.Context, in *SourcePrefix, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/BlockSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) UnblockSource(ctx context.Context, in *SourcePrefix, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/UnblockSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) GetModeration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ModerationState, error) {
+	out := new(ModerationState)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/GetModeration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) ListPendingDeltas(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PendingDeltas, error) {
+	out := new(PendingDeltas)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/ListPendingDeltas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sixelpingAdminClient) DiscardDeltas(ctx context.Context, in *DiscardDeltasRequest, opts ...grpc.CallOption) (*DiscardDeltasResponse, error) {
+	out := new(DiscardDeltasResponse)
+	err := c.cc.Invoke(ctx, "/SixelpingAdmin/DiscardDeltas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SixelpingAdminServer is the server API for SixelpingAdmin service.
+type SixelpingAdminServer interface {
+	ClearCanvas(context.Context, *empty.Empty) (*empty.Empty, error)
+	FillRegion(context.Context, *FillRegionRequest) (*empty.Empty, error)
+	SetPixelTimeout(context.Context, *PixelTimeoutRequest) (*empty.Empty, error)
+	SetFps(context.Context, *FpsRequest) (*empty.Empty, error)
+	SetLogo(context.Context, *LogoRequest) (*empty.Empty, error)
+	PauseIngestion(context.Context, *PauseRequest) (*empty.Empty, error)
+	FreezeDisplay(context.Context, *FreezeRequest) (*empty.Empty, error)
+	ResizeCanvas(context.Context, *ResizeRequest) (*empty.Empty, error)
+	SetReceiverMapping(context.Context, *ReceiverMapping) (*empty.Empty, error)
+	RemoveReceiverMapping(context.Context, *ReceiverId) (*empty.Empty, error)
+	ListReceiverMappings(context.Context, *empty.Empty) (*ReceiverMappings, error)
+	RollbackCanvas(context.Context, *RollbackCanvasRequest) (*RollbackResponse, error)
+	DropSourceDeltas(context.Context, *DropSourceDeltasRequest) (*RollbackResponse, error)
+	AddMask(context.Context, *Mask) (*Mask, error)
+	RemoveMask(context.Context, *MaskId) (*empty.Empty, error)
+	BlockSource(context.Context, *SourcePrefix) (*empty.Empty, error)
+	UnblockSource(context.Context, *SourcePrefix) (*empty.Empty, error)
+	GetModeration(context.Context, *empty.Empty) (*ModerationState, error)
+	ListPendingDeltas(context.Context, *empty.Empty) (*PendingDeltas, error)
+	DiscardDeltas(context.Context, *DiscardDeltasRequest) (*DiscardDeltasResponse, error)
+}
+
+// UnimplementedSixelpingAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedSixelpingAdminServer struct {
+}
+
+func (*UnimplementedSixelpingAdminServer) ClearCanvas(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearCanvas not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) FillRegion(ctx context.Context, req *FillRegionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FillRegion not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) SetPixelTimeout(ctx context.Context, req *PixelTimeoutRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPixelTimeout not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) SetFps(ctx context.Context, req *FpsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFps not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) SetLogo(ctx context.Context, req *LogoRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogo not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) PauseIngestion(ctx context.Context, req *PauseRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseIngestion not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) FreezeDisplay(ctx context.Context, req *FreezeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeDisplay not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) ResizeCanvas(ctx context.Context, req *ResizeRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeCanvas not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) SetReceiverMapping(ctx context.Context, req *ReceiverMapping) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReceiverMapping not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) RemoveReceiverMapping(ctx context.Context, req *ReceiverId) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReceiverMapping not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) ListReceiverMappings(ctx context.Context, req *empty.Empty) (*ReceiverMappings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReceiverMappings not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) RollbackCanvas(ctx context.Context, req *RollbackCanvasRequest) (*RollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackCanvas not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) DropSourceDeltas(ctx context.Context, req *DropSourceDeltasRequest) (*RollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropSourceDeltas not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) AddMask(ctx context.Context, req *Mask) (*Mask, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMask not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) RemoveMask(ctx context.Context, req *MaskId) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMask not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) BlockSource(ctx context.Context, req *SourcePrefix) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockSource not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) UnblockSource(ctx context.Context, req *SourcePrefix) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockSource not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) GetModeration(ctx context.Context, req *empty.Empty) (*ModerationState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModeration not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) ListPendingDeltas(ctx context.Context, req *empty.Empty) (*PendingDeltas, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingDeltas not implemented")
+}
+func (*UnimplementedSixelpingAdminServer) DiscardDeltas(ctx context.Context, req *DiscardDeltasRequest) (*DiscardDeltasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardDeltas not implemented")
+}
+
+func RegisterSixelpingAdminServer(s *grpc.Server, srv SixelpingAdminServer) {
+	s.RegisterService(&_SixelpingAdmin_serviceDesc, srv)
+}
+
+func _SixelpingAdmin_ClearCanvas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).ClearCanvas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/ClearCanvas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).ClearCanvas(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingAdmin_FillRegion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FillRegionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).FillRegion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/FillRegion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).FillRegion(ctx, req.(*FillRegionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SixelpingAdmin_SetPixelTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PixelTimeoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).SetPixelTimeout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/SetPixelTimeout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).SetPixelTimeout(ctx, req.(*PixelTimeoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// SixelpingRendererClient is the client API for SixelpingRenderer service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type SixelpingRendererClient interface {
-	NewDeltaImage(ctx context.Context, in *NewDeltaImageRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CanvasParametersResponse, error)
-	MetricsUpdate(ctx context.Context, in *MetricsDatapoint, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetRenderedImage(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RenderedImageResponse, error)
+func _SixelpingAdmin_SetFps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FpsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).SetFps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/SetFps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).SetFps(ctx, req.(*FpsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type sixelpingRendererClient struct {
-	cc *grpc.ClientConn
+func _SixelpingAdmin_SetLogo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).SetLogo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/SetLogo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).SetLogo(ctx, req.(*LogoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewSixelpingRendererClient(cc *grpc.ClientConn) SixelpingRendererClient {
-	return &sixelpingRendererClient{cc}
+func _SixelpingAdmin_PauseIngestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).PauseIngestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/PauseIngestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).PauseIngestion(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *sixelpingRendererClient) NewDeltaImage(ctx context.Context, in *NewDeltaImageRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/SixelpingRenderer/NewDeltaImage", in, out, opts...)
-	if err != nil {
+func _SixelpingAdmin_FreezeDisplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).FreezeDisplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/FreezeDisplay",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).FreezeDisplay(ctx, req.(*FreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *sixelpingRendererClient) GetCanvasParameters(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CanvasParametersResponse, error) {
-	out := new(CanvasParametersResponse)
-	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetCanvasParameters", in, out, opts...)
-	if err != nil {
+func _SixelpingAdmin_ResizeCanvas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResizeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).ResizeCanvas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/ResizeCanvas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).ResizeCanvas(ctx, req.(*ResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *sixelpingRendererClient) MetricsUpdate(ctx context.Context, in *MetricsDatapoint, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/SixelpingRenderer/MetricsUpdate", in, out, opts...)
-	if err != nil {
+func _SixelpingAdmin_SetReceiverMapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverMapping)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).SetReceiverMapping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/SetReceiverMapping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).SetReceiverMapping(ctx, req.(*ReceiverMapping))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *sixelpingRendererClient) GetRenderedImage(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RenderedImageResponse, error) {
-	out := new(RenderedImageResponse)
-	err := c.cc.Invoke(ctx, "/SixelpingRenderer/GetRenderedImage", in, out, opts...)
-	if err != nil {
+func _SixelpingAdmin_RemoveReceiverMapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReceiverId)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).RemoveReceiverMapping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/RemoveReceiverMapping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).RemoveReceiverMapping(ctx, req.(*ReceiverId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// SixelpingRendererServer is the server API for SixelpingRenderer service.
-type SixelpingRendererServer interface {
-	NewDeltaImage(context.Context, *NewDeltaImageRequest) (*empty.Empty, error)
-	GetCanvasParameters(context.Context, *empty.Empty) (*CanvasParametersResponse, error)
-	MetricsUpdate(context.Context, *MetricsDatapoint) (*empty.Empty, error)
-	GetRenderedImage(context.Context, *empty.Empty) (*RenderedImageResponse, error)
+func _SixelpingAdmin_ListReceiverMappings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).ListReceiverMappings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/ListReceiverMappings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).ListReceiverMappings(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedSixelpingRendererServer can be embedded to have forward compatible implementations.
-type UnimplementedSixelpingRendererServer struct {
+func _SixelpingAdmin_RollbackCanvas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackCanvasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).RollbackCanvas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/RollbackCanvas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).RollbackCanvas(ctx, req.(*RollbackCanvasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedSixelpingRendererServer) NewDeltaImage(ctx context.Context, req *NewDeltaImageRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewDeltaImage not implemented")
-}
-func (*UnimplementedSixelpingRendererServer) GetCanvasParameters(ctx context.Context, req *empty.Empty) (*CanvasParametersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCanvasParameters not implemented")
+func _SixelpingAdmin_DropSourceDeltas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropSourceDeltasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).DropSourceDeltas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/DropSourceDeltas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).DropSourceDeltas(ctx, req.(*DropSourceDeltasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSixelpingRendererServer) MetricsUpdate(ctx context.Context, req *MetricsDatapoint) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsUpdate not implemented")
+
+func _SixelpingAdmin_AddMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Mask)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).AddMask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/AddMask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).AddMask(ctx, req.(*Mask))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedSixelpingRendererServer) GetRenderedImage(ctx context.Context, req *empty.Empty) (*RenderedImageResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRenderedImage not implemented")
+
+func _SixelpingAdmin_RemoveMask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaskId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).RemoveMask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/RemoveMask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).RemoveMask(ctx, req.(*MaskId))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSixelpingRendererServer(s *grpc.Server, srv SixelpingRendererServer) {
-	s.RegisterService(&_SixelpingRenderer_serviceDesc, srv)
+func _SixelpingAdmin_BlockSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SourcePrefix)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SixelpingAdminServer).BlockSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SixelpingAdmin/BlockSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SixelpingAdminServer).BlockSource(ctx, req.(*SourcePrefix))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SixelpingRenderer_NewDeltaImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewDeltaImageRequest)
+func _SixelpingAdmin_UnblockSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SourcePrefix)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SixelpingRendererServer).NewDeltaImage(ctx, in)
+		return srv.(SixelpingAdminServer).UnblockSource(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SixelpingRenderer/NewDeltaImage",
+		FullMethod: "/SixelpingAdmin/UnblockSource",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SixelpingRendererServer).NewDeltaImage(ctx, req.(*NewDeltaImageRequest))
+		return srv.(SixelpingAdminServer).UnblockSource(ctx, req.(*SourcePrefix))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SixelpingRenderer_GetCanvasParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SixelpingAdmin_GetModeration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SixelpingRendererServer).GetCanvasParameters(ctx, in)
+		return srv.(SixelpingAdminServer).GetModeration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SixelpingRenderer/GetCanvasParameters",
+		FullMethod: "/SixelpingAdmin/GetModeration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SixelpingRendererServer).GetCanvasParameters(ctx, req.(*empty.Empty))
+		return srv.(SixelpingAdminServer).GetModeration(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SixelpingRenderer_MetricsUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsDatapoint)
+func _SixelpingAdmin_ListPendingDeltas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SixelpingRendererServer).MetricsUpdate(ctx, in)
+		return srv.(SixelpingAdminServer).ListPendingDeltas(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SixelpingRenderer/MetricsUpdate",
+		FullMethod: "/SixelpingAdmin/ListPendingDeltas",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SixelpingRendererServer).MetricsUpdate(ctx, req.(*MetricsDatapoint))
+		return srv.(SixelpingAdminServer).ListPendingDeltas(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SixelpingRenderer_GetRenderedImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _SixelpingAdmin_DiscardDeltas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDeltasRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SixelpingRendererServer).GetRenderedImage(ctx, in)
+		return srv.(SixelpingAdminServer).DiscardDeltas(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/SixelpingRenderer/GetRenderedImage",
+		FullMethod: "/SixelpingAdmin/DiscardDeltas",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SixelpingRendererServer).GetRenderedImage(ctx, req.(*empty.Empty))
+		return srv.(SixelpingAdminServer).DiscardDeltas(ctx, req.(*DiscardDeltasRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _SixelpingRenderer_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "SixelpingRenderer",
-	HandlerType: (*SixelpingRendererServer)(nil),
+var _SixelpingAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "SixelpingAdmin",
+	HandlerType: (*SixelpingAdminServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "NewDeltaImage",
-			Handler:    _SixelpingRenderer_NewDeltaImage_Handler,
+			MethodName: "ClearCanvas",
+			Handler:    _SixelpingAdmin_ClearCanvas_Handler,
 		},
 		{
-			MethodName: "GetCanvasParameters",
-			Handler:    _SixelpingRenderer_GetCanvasParameters_Handler,
+			MethodName: "FillRegion",
+			Handler:    _SixelpingAdmin_FillRegion_Handler,
 		},
 		{
-			MethodName: "MetricsUpdate",
-			Handler:    _SixelpingRenderer_MetricsUpdate_Handler,
+			MethodName: "SetPixelTimeout",
+			Handler:    _SixelpingAdmin_SetPixelTimeout_Handler,
 		},
 		{
-			MethodName: "GetRenderedImage",
-			Handler:    _SixelpingRenderer_GetRenderedImage_Handler,
+			MethodName: "SetFps",
+			Handler:    _SixelpingAdmin_SetFps_Handler,
+		},
+		{
+			MethodName: "SetLogo",
+			Handler:    _SixelpingAdmin_SetLogo_Handler,
+		},
+		{
+			MethodName: "PauseIngestion",
+			Handler:    _SixelpingAdmin_PauseIngestion_Handler,
+		},
+		{
+			MethodName: "FreezeDisplay",
+			Handler:    _SixelpingAdmin_FreezeDisplay_Handler,
+		},
+		{
+			MethodName: "ResizeCanvas",
+			Handler:    _SixelpingAdmin_ResizeCanvas_Handler,
+		},
+		{
+			MethodName: "SetReceiverMapping",
+			Handler:    _SixelpingAdmin_SetReceiverMapping_Handler,
+		},
+		{
+			MethodName: "RemoveReceiverMapping",
+			Handler:    _SixelpingAdmin_RemoveReceiverMapping_Handler,
+		},
+		{
+			MethodName: "ListReceiverMappings",
+			Handler:    _SixelpingAdmin_ListReceiverMappings_Handler,
+		},
+		{
+			MethodName: "RollbackCanvas",
+			Handler:    _SixelpingAdmin_RollbackCanvas_Handler,
+		},
+		{
+			MethodName: "DropSourceDeltas",
+			Handler:    _SixelpingAdmin_DropSourceDeltas_Handler,
+		},
+		{
+			MethodName: "AddMask",
+			Handler:    _SixelpingAdmin_AddMask_Handler,
+		},
+		{
+			MethodName: "RemoveMask",
+			Handler:    _SixelpingAdmin_RemoveMask_Handler,
+		},
+		{
+			MethodName: "BlockSource",
+			Handler:    _SixelpingAdmin_BlockSource_Handler,
+		},
+		{
+			MethodName: "UnblockSource",
+			Handler:    _SixelpingAdmin_UnblockSource_Handler,
+		},
+		{
+			MethodName: "GetModeration",
+			Handler:    _SixelpingAdmin_GetModeration_Handler,
+		},
+		{
+			MethodName: "ListPendingDeltas",
+			Handler:    _SixelpingAdmin_ListPendingDeltas_Handler,
+		},
+		{
+			MethodName: "DiscardDeltas",
+			Handler:    _SixelpingAdmin_DiscardDeltas_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
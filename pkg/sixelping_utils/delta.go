@@ -0,0 +1,175 @@
+package sixelping_utils
+
+import (
+	"fmt"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+// ApplyDelta applies the full-canvas and sparse parts of a delta request. The
+// delta is validated as a whole first, an invalid delta changes no pixels.
+func ApplyDelta(canvas *canvaspkg.Canvas, req *pb.NewDeltaImageRequest) error {
+	d, err := CanvasDelta(req)
+	if err != nil {
+		return err
+	}
+	return canvas.Apply(d)
+}
+
+// CanvasDelta converts a delta request to a canvas delta.
+func CanvasDelta(req *pb.NewDeltaImageRequest) (canvaspkg.Delta, error) {
+	pixels, runs, err := SparseDelta(req)
+	if err != nil {
+		return canvaspkg.Delta{}, err
+	}
+	d := canvaspkg.Delta{Pixels: pixels, Runs: runs}
+	if len(req.GetImage()) > 0 {
+		d.Image = req.GetImage()
+		d.Format = canvaspkg.PixelFormat(req.GetFormat())
+		d.ImageSource, err = deltaSource(req, req.GetImageSource())
+		if err != nil {
+			return canvaspkg.Delta{}, err
+		}
+	}
+	return d, nil
+}
+
+// SparseDelta converts the sparse part of a delta request to canvas pixels and runs.
+func SparseDelta(req *pb.NewDeltaImageRequest) ([]canvaspkg.Pixel, []canvaspkg.Run, error) {
+	pixels := make([]canvaspkg.Pixel, len(req.GetPixels()))
+	for i, p := range req.GetPixels() {
+		source, err := deltaSource(req, p.GetSource())
+		if err != nil {
+			return nil, nil, err
+		}
+		pixels[i] = canvaspkg.Pixel{
+			X:      int(p.GetX()),
+			Y:      int(p.GetY()),
+			R:      uint8(p.GetRgb() >> 16),
+			G:      uint8(p.GetRgb() >> 8),
+			B:      uint8(p.GetRgb()),
+			Source: source,
+		}
+	}
+
+	runs := make([]canvaspkg.Run, len(req.GetRuns()))
+	for i, r := range req.GetRuns() {
+		source, err := deltaSource(req, r.GetSource())
+		if err != nil {
+			return nil, nil, err
+		}
+		runs[i] = canvaspkg.Run{
+			X:      int(r.GetX()),
+			Y:      int(r.GetY()),
+			Length: int(r.GetLength()),
+			R:      uint8(r.GetRgb() >> 16),
+			G:      uint8(r.GetRgb() >> 8),
+			B:      uint8(r.GetRgb()),
+			Source: source,
+		}
+	}
+
+	return pixels, runs, nil
+}
+
+// deltaSource resolves a source reference of a delta request, 0 is no source.
+func deltaSource(req *pb.NewDeltaImageRequest, ref uint32) (string, error) {
+	if ref == 0 {
+		return "", nil
+	}
+	if int(ref) > len(req.GetSources()) {
+		return "", fmt.Errorf("%w: source %d outside of %d sources", canvaspkg.ErrInvalidDelta, ref, len(req.GetSources()))
+	}
+	return req.GetSources()[ref-1], nil
+}
+
+// FilterDelta drops every pixel of a delta request for a width x height canvas
+// that keep rejects. The request is returned unchanged when all pixels are
+// kept, otherwise a filtered copy is returned together with the number of
+// dropped pixels. Pixels and runs outside of the canvas are rejected.
+func FilterDelta(req *pb.NewDeltaImageRequest, width int, height int, keep func(x, y int, source string) bool) (*pb.NewDeltaImageRequest, int, error) {
+	dropped := 0
+	var image []byte
+	if len(req.GetImage()) > 0 {
+		format := canvaspkg.PixelFormat(req.GetFormat())
+		err := canvaspkg.ValidateDelta(req.GetImage(), format, width, height)
+		if err != nil {
+			return nil, 0, err
+		}
+		source, err := deltaSource(req, req.GetImageSource())
+		if err != nil {
+			return nil, 0, err
+		}
+
+		bpp := format.BytesPerPixel()
+		for i := 0; i < width*height; i++ {
+			p := req.GetImage()[i*bpp : (i+1)*bpp]
+			if _, _, _, set := format.DecodePixel(p); !set || keep(i%width, i/width, source) {
+				continue
+			}
+			if image == nil {
+				image = append([]byte{}, req.GetImage()...)
+			}
+			// All formats treat an all zero pixel as unchanged
+			for j := range p {
+				image[i*bpp+j] = 0
+			}
+			dropped++
+		}
+	}
+
+	pixels := make([]*pb.DeltaPixel, 0, len(req.GetPixels()))
+	for _, p := range req.GetPixels() {
+		source, err := deltaSource(req, p.GetSource())
+		if err != nil {
+			return nil, 0, err
+		}
+		if p.GetX() >= uint32(width) || p.GetY() >= uint32(height) {
+			return nil, 0, fmt.Errorf("%w: pixel (%d, %d) outside of %dx%d canvas", canvaspkg.ErrInvalidDelta, p.GetX(), p.GetY(), width, height)
+		}
+		if keep(int(p.GetX()), int(p.GetY()), source) {
+			pixels = append(pixels, p)
+		} else {
+			dropped++
+		}
+	}
+
+	runs := make([]*pb.DeltaRun, 0, len(req.GetRuns()))
+	for _, r := range req.GetRuns() {
+		source, err := deltaSource(req, r.GetSource())
+		if err != nil {
+			return nil, 0, err
+		}
+		if uint64(r.GetX())+uint64(r.GetLength()) > uint64(width) || int(r.GetY()) >= height {
+			return nil, 0, fmt.Errorf("%w: run (%d, %d)+%d outside of %dx%d canvas", canvaspkg.ErrInvalidDelta, r.GetX(), r.GetY(), r.GetLength(), width, height)
+		}
+
+		// Split runs around dropped pixels
+		start := r.GetX()
+		for x := r.GetX(); x <= r.GetX()+r.GetLength(); x++ {
+			if x < r.GetX()+r.GetLength() && keep(int(x), int(r.GetY()), source) {
+				continue
+			}
+			if x > start {
+				runs = append(runs, &pb.DeltaRun{X: start, Y: r.GetY(), Length: x - start, Rgb: r.GetRgb(), Source: r.GetSource()})
+			}
+			if x < r.GetX()+r.GetLength() {
+				dropped++
+			}
+			start = x + 1
+		}
+	}
+
+	if dropped == 0 {
+		return req, 0, nil
+	}
+
+	filtered := *req
+	filtered.Pixels = pixels
+	filtered.Runs = runs
+	if image != nil {
+		filtered.Image = image
+	}
+	return &filtered, dropped, nil
+}
@@ -0,0 +1,105 @@
+package sixelping_utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	canvaspkg "github.com/sixelping/sixelping-renderer/pkg/canvas"
+	pb "github.com/sixelping/sixelping-renderer/pkg/sixelping_command"
+)
+
+func TestFilterDeltaSplitsRuns(t *testing.T) {
+	req := &pb.NewDeltaImageRequest{
+		Sources: []string{"a", "b"},
+		Runs: []*pb.DeltaRun{
+			{X: 0, Y: 0, Length: 8, Rgb: 0xff0000, Source: 1},
+			{X: 2, Y: 1, Length: 3, Rgb: 0x00ff00, Source: 2},
+			{X: 5, Y: 2, Length: 0, Rgb: 0x0000ff},
+		},
+	}
+	tests := []struct {
+		name    string
+		keep    func(x, y int, source string) bool
+		runs    []*pb.DeltaRun
+		dropped int
+	}{
+		{"keep all", func(x, y int, source string) bool { return true }, req.Runs, 0},
+		{"drop middle", func(x, y int, source string) bool { return y != 0 || x < 3 || x > 4 }, []*pb.DeltaRun{
+			{X: 0, Y: 0, Length: 3, Rgb: 0xff0000, Source: 1},
+			{X: 5, Y: 0, Length: 3, Rgb: 0xff0000, Source: 1},
+			{X: 2, Y: 1, Length: 3, Rgb: 0x00ff00, Source: 2},
+		}, 2},
+		{"drop ends", func(x, y int, source string) bool { return x != 0 && x != 7 && x != 4 }, []*pb.DeltaRun{
+			{X: 1, Y: 0, Length: 3, Rgb: 0xff0000, Source: 1},
+			{X: 5, Y: 0, Length: 2, Rgb: 0xff0000, Source: 1},
+			{X: 2, Y: 1, Length: 2, Rgb: 0x00ff00, Source: 2},
+		}, 4},
+		{"drop every other", func(x, y int, source string) bool { return source != "b" || x%2 == 0 }, []*pb.DeltaRun{
+			{X: 0, Y: 0, Length: 8, Rgb: 0xff0000, Source: 1},
+			{X: 2, Y: 1, Length: 1, Rgb: 0x00ff00, Source: 2},
+			{X: 4, Y: 1, Length: 1, Rgb: 0x00ff00, Source: 2},
+		}, 1},
+		{"drop source", func(x, y int, source string) bool { return source != "a" }, []*pb.DeltaRun{
+			{X: 2, Y: 1, Length: 3, Rgb: 0x00ff00, Source: 2},
+		}, 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, dropped, err := FilterDelta(req, 8, 3, test.keep)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dropped != test.dropped {
+				t.Errorf("Dropped %d pixels, want %d", dropped, test.dropped)
+			}
+			if !reflect.DeepEqual(filtered.Runs, test.runs) {
+				t.Errorf("Filtered runs are %v, want %v", filtered.Runs, test.runs)
+			}
+			if dropped > 0 && filtered == req {
+				t.Errorf("Request was not copied")
+			}
+		})
+	}
+	if len(req.Runs) != 3 || req.Runs[0].Length != 8 {
+		t.Errorf("Request was modified: %v", req.Runs)
+	}
+}
+
+func TestFilterDeltaImageAndPixels(t *testing.T) {
+	req := &pb.NewDeltaImageRequest{
+		Image:  []byte{1, 1, 1, 0, 0, 0, 2, 2, 2, 3, 3, 3},
+		Format: pb.PixelFormat_RGB24,
+		Pixels: []*pb.DeltaPixel{{X: 0, Y: 0, Rgb: 1}, {X: 1, Y: 1, Rgb: 2}},
+	}
+	filtered, dropped, err := FilterDelta(req, 2, 2, func(x, y int, source string) bool { return x != y })
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The unset pixel at (1, 0) is neither kept nor dropped
+	if dropped != 4 {
+		t.Errorf("Dropped %d pixels, want 4", dropped)
+	}
+	if want := []byte{0, 0, 0, 0, 0, 0, 2, 2, 2, 0, 0, 0}; !reflect.DeepEqual(filtered.Image, want) {
+		t.Errorf("Filtered image is %v, want %v", filtered.Image, want)
+	}
+	if len(filtered.Pixels) != 0 {
+		t.Errorf("Filtered pixels are %v", filtered.Pixels)
+	}
+	if req.Image[0] != 1 {
+		t.Errorf("Request image was modified")
+	}
+}
+
+func TestFilterDeltaRejectsOutside(t *testing.T) {
+	for _, req := range []*pb.NewDeltaImageRequest{
+		{Runs: []*pb.DeltaRun{{X: 6, Y: 0, Length: 3}}},
+		{Pixels: []*pb.DeltaPixel{{X: 8, Y: 0}}},
+		{Pixels: []*pb.DeltaPixel{{X: 0, Y: 1}}},
+	} {
+		_, _, err := FilterDelta(req, 8, 1, func(x, y int, source string) bool { return true })
+		if !errors.Is(err, canvaspkg.ErrInvalidDelta) {
+			t.Errorf("FilterDelta(%v) returned %v, want ErrInvalidDelta", req, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package sixelping_utils
+
+import (
+	"net"
+)
+
+// SourceIP returns the address of a pixel or delta source, which may be an
+// address, a prefix in CIDR notation or a host:port pair. It returns nil for
+// sources that are none of these.
+func SourceIP(source string) net.IP {
+	if ip := net.ParseIP(source); ip != nil {
+		return ip
+	}
+	if ip, _, err := net.ParseCIDR(source); err == nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(source); err == nil {
+		return net.ParseIP(host)
+	}
+	return nil
+}
+
+// SourcePrefix returns the prefix of length bits containing a source in CIDR
+// notation. Sources that are prefixes themselves keep their length when it is
+// shorter, IPv4 sources always map to their /32. It returns an empty string
+// for sources without an address.
+func SourcePrefix(source string, bits int) string {
+	ip := SourceIP(source)
+	if ip == nil {
+		return ""
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4, Mask: net.CIDRMask(32, 32)}).String()
+	}
+	if _, n, err := net.ParseCIDR(source); err == nil {
+		if ones, _ := n.Mask.Size(); ones < bits {
+			bits = ones
+		}
+	}
+	mask := net.CIDRMask(bits, 128)
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// SourceMatcher returns a function matching sources by address, so a source
+// reconnecting from another port still matches, or by prefix when source is in
+// CIDR notation. Sources without an address only match themselves.
+func SourceMatcher(source string) func(string) bool {
+	if _, n, err := net.ParseCIDR(source); err == nil {
+		return func(s string) bool {
+			ip := SourceIP(s)
+			return ip != nil && n.Contains(ip)
+		}
+	}
+	if ip := SourceIP(source); ip != nil {
+		return func(s string) bool {
+			other := SourceIP(s)
+			return other != nil && other.Equal(ip)
+		}
+	}
+	return func(s string) bool {
+		return s == source
+	}
+}
@@ -0,0 +1,30 @@
+package sixelping_utils
+
+import "testing"
+
+func TestSourceMatcher(t *testing.T) {
+	tests := []struct {
+		source  string
+		matches []string
+		misses  []string
+	}{
+		{"[2001:db8::1]:1234", []string{"[2001:db8::1]:5678", "2001:db8::1"}, []string{"[2001:db8::2]:1234", "bufconn"}},
+		{"2001:db8::1", []string{"[2001:db8::1]:5678"}, []string{"[2001:db8::1:1]:5678"}},
+		{"2001:db8::/64", []string{"[2001:db8::1]:1", "[2001:db8::ffff]:2"}, []string{"[2001:db8:0:1::1]:1", ""}},
+		{"192.0.2.1:80", []string{"192.0.2.1:81"}, []string{"192.0.2.2:80"}},
+		{"bufconn", []string{"bufconn"}, []string{"[2001:db8::1]:1"}},
+	}
+	for _, test := range tests {
+		matches := SourceMatcher(test.source)
+		for _, s := range test.matches {
+			if !matches(s) {
+				t.Errorf("%q does not match %q", test.source, s)
+			}
+		}
+		for _, s := range test.misses {
+			if matches(s) {
+				t.Errorf("%q matches %q", test.source, s)
+			}
+		}
+	}
+}